@@ -18,6 +18,7 @@ package v1alpha1
 
 import (
 	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
@@ -80,3 +81,56 @@ type BackupPlan interface {
 	GetSecretData() ([]byte, error)
 	New() BackupPlan
 }
+
+// +kubebuilder:object:generate:=false
+
+// WorkerVolumeProvider is implemented by BackupPlans, which require volumes
+// to be mounted into the worker in addition to the ones of the spec
+type WorkerVolumeProvider interface {
+	GetWorkerVolumes() ([]corev1.Volume, []corev1.VolumeMount)
+}
+
+// +kubebuilder:object:generate:=false
+
+// WorkerRBACProvider is implemented by BackupPlans, which require the worker
+// to access the Kubernetes API. If no ServiceAccount name is returned, a
+// ServiceAccount bound to a Role with the returned rules will be managed.
+type WorkerRBACProvider interface {
+	GetWorkerServiceAccountName() string
+	GetWorkerPolicyRules() []rbacv1.PolicyRule
+}
+
+// +kubebuilder:object:generate:=false
+
+// WorkerImageProvider is implemented by BackupPlans, which run the worker
+// within a custom image instead of the default worker image
+type WorkerImageProvider interface {
+	GetWorkerImage() string
+}
+
+// +kubebuilder:object:generate:=false
+
+// WorkerSecurityContextProvider is implemented by BackupPlans, which require
+// the worker to run with a specific user or group
+type WorkerSecurityContextProvider interface {
+	GetWorkerSecurityContext() *corev1.PodSecurityContext
+}
+
+// +kubebuilder:object:generate:=false
+
+// AdditionalWorkerProvider is implemented by BackupPlans, which run further
+// worker commands on their own schedule next to the backup itself
+type AdditionalWorkerProvider interface {
+	GetAdditionalWorkers() []AdditionalWorker
+}
+
+// +kubebuilder:object:generate:=false
+
+// AdditionalWorker describes a CronJob running the worker with the provided
+// command. The CronJob is named after the plan suffixed by the name of the
+// worker. An empty schedule disables the worker.
+type AdditionalWorker struct {
+	Name     string
+	Schedule string
+	Cmd      string
+}
@@ -25,6 +25,11 @@ import (
 const ConsulBackupPlanKind = "ConsulBackupPlan"
 const ConsulBackupPlanWorkerCommand = "consul"
 
+const (
+	ConsulBackupModeSnapshot = "snapshot"
+	ConsulBackupModeKV       = "kv"
+)
+
 // ConsulBackupPlanSpec defines the desired state of ConsulBackupPlan
 type ConsulBackupPlanSpec struct {
 	BackupPlanSpec `json:",inline"`
@@ -40,6 +45,56 @@ type ConsulBackupPlanSpec struct {
 	// +optional
 	// Password to authenticate with consul
 	Password string `json:"password,omitempty"`
+
+	// +optional
+	// ACL token to authenticate with consul. Falls back to CONSUL_HTTP_TOKEN.
+	Token string `json:"token,omitempty"`
+
+	// +optional
+	// Datacenter to backup. Defaults to the datacenter of the agent.
+	Datacenter string `json:"datacenter,omitempty"`
+
+	// +optional
+	// Allow followers to serve the snapshot or export instead of only the
+	// leader, which reduces the load of the leader, but may miss the latest
+	// writes.
+	Stale bool `json:"stale,omitempty"`
+
+	// +optional
+	// TLS configuration using certificates mounted via volumeMounts. If set,
+	// HTTPS is used.
+	TLS *ConsulTLS `json:"tls,omitempty"`
+
+	// +optional
+	// +kubebuilder:validation:Enum=snapshot;kv
+	// Mode of the backup. Either a raft snapshot of the whole cluster
+	// (default) or an export of the KV store as JSON compatible with
+	// `consul kv export`.
+	Mode string `json:"mode,omitempty"`
+
+	// +optional
+	// Prefixes of the KV store to export, if mode is kv. Defaults to the
+	// whole KV store.
+	Prefixes []string `json:"prefixes,omitempty"`
+}
+
+type ConsulTLS struct {
+	// +optional
+	// Path of the CA certificate to verify consul with. Falls back to
+	// CONSUL_CACERT.
+	CAFile string `json:"caFile,omitempty"`
+
+	// +optional
+	// Path of the client certificate. Falls back to CONSUL_CLIENT_CERT.
+	CertFile string `json:"certFile,omitempty"`
+
+	// +optional
+	// Path of the client key. Falls back to CONSUL_CLIENT_KEY.
+	KeyFile string `json:"keyFile,omitempty"`
+
+	// +optional
+	// Skip the verification of the server certificate
+	InsecureSkipVerify bool `json:"insecureSkipVerify,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -16,10 +16,77 @@ limitations under the License.
 
 package v1alpha1
 
+import (
+	corev1 "k8s.io/api/core/v1"
+)
+
+const DestinationVolumeName = "destination"
+const DestinationVolumeMountPath = "/mnt/destination"
+
+// DefaultDestinationVolumeFSGroup is the group of the worker image, so the
+// worker can write to freshly provisioned volumes
+const DefaultDestinationVolumeFSGroup int64 = 65532
+
 type Destination struct {
 	// +optional
 	// Configuration for S3 as backup target
 	S3 *S3 `json:"s3,omitempty"`
+	// +optional
+	// Configuration for Google Cloud Storage as backup target
+	GCS *GCS `json:"gcs,omitempty"`
+	// +optional
+	// Configuration for Azure Blob Storage as backup target
+	Azure *Azure `json:"azure,omitempty"`
+	// +optional
+	// Configuration for an SFTP server as backup target
+	SFTP *SFTP `json:"sftp,omitempty"`
+	// +optional
+	// Configuration for a PersistentVolumeClaim as backup target
+	Volume *Volume `json:"volume,omitempty"`
+	// +optional
+	// Configuration for a WebDAV server, e.g. Nextcloud, as backup target
+	WebDAV *WebDAV `json:"webdav,omitempty"`
+}
+
+// GetWorkerVolumes returns the volume the worker requires to store backups,
+// if the destination is a PersistentVolumeClaim
+func (d *Destination) GetWorkerVolumes() ([]corev1.Volume, []corev1.VolumeMount) {
+	if d.Volume == nil {
+		return nil, nil
+	}
+	volumes := []corev1.Volume{
+		{
+			Name: DestinationVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+					ClaimName: d.Volume.ClaimName,
+				},
+			},
+		},
+	}
+	volumeMounts := []corev1.VolumeMount{
+		{
+			Name:      DestinationVolumeName,
+			MountPath: DestinationVolumeMountPath,
+			SubPath:   d.Volume.SubPath,
+		},
+	}
+	return volumes, volumeMounts
+}
+
+// GetWorkerSecurityContext returns the security context the worker requires
+// to store backups, if the destination is a PersistentVolumeClaim
+func (d *Destination) GetWorkerSecurityContext() *corev1.PodSecurityContext {
+	if d.Volume == nil {
+		return nil
+	}
+	fsGroup := DefaultDestinationVolumeFSGroup
+	if d.Volume.FSGroup != nil {
+		fsGroup = *d.Volume.FSGroup
+	}
+	return &corev1.PodSecurityContext{
+		FSGroup: &fsGroup,
+	}
 }
 
 type S3 struct {
@@ -40,3 +107,115 @@ type S3 struct {
 	// +optional
 	PartSize int64 `json:"partSize,omitempty"`
 }
+
+type GCS struct {
+	// +optional
+	// Endpoint of the JSON API. Defaults to https://storage.googleapis.com.
+	Endpoint string `json:"endpoint,omitempty"`
+	Bucket   string `json:"bucket"`
+	// +optional
+	// Project used to create the bucket, if it does not exist
+	Project string `json:"project,omitempty"`
+	// +optional
+	// Service account key in JSON format. Falls back to GCS_CREDENTIALS and
+	// the application default credentials, e.g. of workload identity.
+	Credentials string `json:"credentials,omitempty"`
+	// +optional
+	// Customer-supplied encryption key of 32 bytes. Falls back to
+	// GCS_ENCRYPTION_KEY.
+	EncryptionKey string `json:"encryptionKey,omitempty"`
+	// +optional
+	// +kubebuilder:validation:Minimum=262144
+	// Size of the chunks of resumable uploads in bytes. Has to be a multiple
+	// of 256 KiB and defaults to 8 MiB.
+	ChunkSize int64 `json:"chunkSize,omitempty"`
+}
+
+type Azure struct {
+	// +optional
+	// Endpoint of the blob service. Defaults to the public endpoint of the
+	// account, e.g. https://<account>.blob.core.windows.net.
+	Endpoint  string `json:"endpoint,omitempty"`
+	Account   string `json:"account"`
+	Container string `json:"container"`
+	// +optional
+	// SAS token with permissions to create, write, list and delete blobs.
+	// Falls back to AZURE_STORAGE_SAS_TOKEN.
+	SASToken string `json:"sasToken,omitempty"`
+	// +optional
+	// Base64 encoded shared key of the account, used if no SAS token is
+	// configured. Falls back to AZURE_STORAGE_KEY.
+	SharedKey string `json:"sharedKey,omitempty"`
+	// +optional
+	// +kubebuilder:validation:Enum=Hot;Cool;Archive
+	// Access tier of the stored blobs. Defaults to the tier of the account.
+	AccessTier string `json:"accessTier,omitempty"`
+	// +optional
+	// Size of the staged blocks in bytes. Defaults to 8 MiB.
+	BlockSize int64 `json:"blockSize,omitempty"`
+}
+
+type SFTP struct {
+	Host string `json:"host"`
+	// +optional
+	// Port of the SSH server. Defaults to 22.
+	Port     int32  `json:"port,omitempty"`
+	Username string `json:"username"`
+	// +optional
+	// Falls back to SFTP_PASSWORD.
+	Password string `json:"password,omitempty"`
+	// +optional
+	// Path of the private key in the worker, e.g. of a secret mounted via
+	// the volumes of the plan.
+	PrivateKeyFile string `json:"privateKeyFile,omitempty"`
+	// +optional
+	// Passphrase of an encrypted private key. Falls back to
+	// SFTP_PRIVATE_KEY_PASSPHRASE.
+	PrivateKeyPassphrase string `json:"privateKeyPassphrase,omitempty"`
+	// Public key of the server in authorized_keys format, e.g.
+	// "ssh-ed25519 AAAA...". Connections to servers presenting any other key
+	// are rejected.
+	HostKey string `json:"hostKey"`
+	// +optional
+	// Remote directory backups are stored in below <namespace>/<name>.
+	// Defaults to the working directory of the user.
+	Directory string `json:"directory,omitempty"`
+}
+
+type Volume struct {
+	// Name of the PersistentVolumeClaim backups are stored in below
+	// <namespace>/<name>, which will be mounted into the worker
+	ClaimName string `json:"claimName"`
+	// +optional
+	// Path within the volume. Defaults to the root of the volume.
+	SubPath string `json:"subPath,omitempty"`
+	// +optional
+	// Group owning the volume, which the worker is added to. Defaults to
+	// 65532, the group of the worker image.
+	FSGroup *int64 `json:"fsGroup,omitempty"`
+}
+
+type WebDAV struct {
+	// URL of the collection backups are stored in below <namespace>/<name>,
+	// e.g. https://cloud.example.com/remote.php/dav/files/<user>
+	URL string `json:"url"`
+	// +optional
+	// URL of the upload collection of Nextcloud used for chunked uploads,
+	// e.g. https://cloud.example.com/remote.php/dav/uploads/<user>. If
+	// empty, backups are uploaded with a single request.
+	UploadsURL string `json:"uploadsURL,omitempty"`
+	// +optional
+	Username string `json:"username,omitempty"`
+	// +optional
+	// Password or app password used for basic authentication. Falls back to
+	// WEBDAV_PASSWORD.
+	Password string `json:"password,omitempty"`
+	// +optional
+	// Token used for bearer authentication instead of basic authentication.
+	// Falls back to WEBDAV_BEARER_TOKEN.
+	BearerToken string `json:"bearerToken,omitempty"`
+	// +optional
+	// Size of the chunks of chunked uploads in bytes. Has to be at least
+	// 5 MiB and defaults to 10 MiB.
+	ChunkSize int64 `json:"chunkSize,omitempty"`
+}
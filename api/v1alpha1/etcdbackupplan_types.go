@@ -0,0 +1,124 @@
+/*
+Copyright 2020 Backup Operator Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"encoding/json"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const EtcdBackupPlanKind = "EtcdBackupPlan"
+const EtcdBackupPlanWorkerCommand = "etcd"
+
+// EtcdBackupPlanSpec defines the desired state of EtcdBackupPlan
+type EtcdBackupPlanSpec struct {
+	BackupPlanSpec `json:",inline"`
+
+	// Endpoints of the etcd cluster. Environment variables
+	// will be evaluated before usage.
+	Endpoints []string `json:"endpoints"`
+
+	// +optional
+	// Username to authenticate with etcd
+	Username string `json:"username,omitempty"`
+
+	// +optional
+	// Password to authenticate with etcd
+	Password string `json:"password,omitempty"`
+
+	// +optional
+	// TLS configuration using certificates mounted via volumeMounts
+	TLS *EtcdTLS `json:"tls,omitempty"`
+}
+
+type EtcdTLS struct {
+	// +optional
+	// Path of the CA certificate to verify etcd with
+	CAFile string `json:"caFile,omitempty"`
+
+	// +optional
+	// Path of the client certificate
+	CertFile string `json:"certFile,omitempty"`
+
+	// +optional
+	// Path of the client key
+	KeyFile string `json:"keyFile,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// EtcdBackupPlan is the Schema for the etcdbackupplans API
+type EtcdBackupPlan struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   EtcdBackupPlanSpec `json:"spec,omitempty"`
+	Status BackupPlanStatus   `json:"status,omitempty"`
+}
+
+func (p *EtcdBackupPlan) GetTypeMeta() *metav1.TypeMeta {
+	return &p.TypeMeta
+}
+
+func (p *EtcdBackupPlan) GetObjectMeta() *metav1.ObjectMeta {
+	return &p.ObjectMeta
+}
+
+func (p *EtcdBackupPlan) GetSpec() *BackupPlanSpec {
+	return &p.Spec.BackupPlanSpec
+}
+
+func (p *EtcdBackupPlan) GetStatus() *BackupPlanStatus {
+	return &p.Status
+}
+
+func (p *EtcdBackupPlan) GetKind() string {
+	return EtcdBackupPlanKind
+}
+
+func (p *EtcdBackupPlan) GetCmd() string {
+	return EtcdBackupPlanWorkerCommand
+}
+
+func (p *EtcdBackupPlan) GetSecretData() ([]byte, error) {
+	reduced := EtcdBackupPlan{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: p.Namespace,
+			Name:      p.Name,
+		},
+		Spec: p.Spec,
+	}
+	return json.Marshal(&reduced)
+}
+
+func (p *EtcdBackupPlan) New() BackupPlan {
+	return &EtcdBackupPlan{}
+}
+
+// +kubebuilder:object:root=true
+
+// EtcdBackupPlanList contains a list of EtcdBackupPlan
+type EtcdBackupPlanList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []EtcdBackupPlan `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&EtcdBackupPlan{}, &EtcdBackupPlanList{})
+}
@@ -0,0 +1,111 @@
+/*
+Copyright 2020 Backup Operator Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"encoding/json"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const ExecBackupPlanKind = "ExecBackupPlan"
+const ExecBackupPlanWorkerCommand = "exec"
+
+// ExecBackupPlanSpec defines the desired state of ExecBackupPlan
+type ExecBackupPlanSpec struct {
+	BackupPlanSpec `json:",inline"`
+
+	// Image containing the tools required to create the backup. The worker
+	// will be copied into the image, so it needs to be linux based.
+	Image string `json:"image"`
+
+	// +kubebuilder:validation:MinItems=1
+	// Command to run. Its stdout is stored as backup and a non-zero exit code
+	// fails the backup. Environment variables will be evaluated before usage.
+	Command []string `json:"command"`
+
+	// +optional
+	// Extension of the stored backups, e.g. "sql.gz". Defaults to "out".
+	Extension string `json:"extension,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ExecBackupPlan is the Schema for the execbackupplans API
+type ExecBackupPlan struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ExecBackupPlanSpec `json:"spec,omitempty"`
+	Status BackupPlanStatus   `json:"status,omitempty"`
+}
+
+func (p *ExecBackupPlan) GetTypeMeta() *metav1.TypeMeta {
+	return &p.TypeMeta
+}
+
+func (p *ExecBackupPlan) GetObjectMeta() *metav1.ObjectMeta {
+	return &p.ObjectMeta
+}
+
+func (p *ExecBackupPlan) GetSpec() *BackupPlanSpec {
+	return &p.Spec.BackupPlanSpec
+}
+
+func (p *ExecBackupPlan) GetStatus() *BackupPlanStatus {
+	return &p.Status
+}
+
+func (p *ExecBackupPlan) GetKind() string {
+	return ExecBackupPlanKind
+}
+
+func (p *ExecBackupPlan) GetCmd() string {
+	return ExecBackupPlanWorkerCommand
+}
+
+func (p *ExecBackupPlan) GetWorkerImage() string {
+	return p.Spec.Image
+}
+
+func (p *ExecBackupPlan) GetSecretData() ([]byte, error) {
+	reduced := ExecBackupPlan{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: p.Namespace,
+			Name:      p.Name,
+		},
+		Spec: p.Spec,
+	}
+	return json.Marshal(&reduced)
+}
+
+func (p *ExecBackupPlan) New() BackupPlan {
+	return &ExecBackupPlan{}
+}
+
+// +kubebuilder:object:root=true
+
+// ExecBackupPlanList contains a list of ExecBackupPlan
+type ExecBackupPlanList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ExecBackupPlan `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ExecBackupPlan{}, &ExecBackupPlanList{})
+}
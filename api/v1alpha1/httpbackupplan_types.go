@@ -0,0 +1,132 @@
+/*
+Copyright 2020 Backup Operator Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"encoding/json"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const HTTPBackupPlanKind = "HTTPBackupPlan"
+const HTTPBackupPlanWorkerCommand = "http"
+
+// HTTPBackupPlanSpec defines the desired state of HTTPBackupPlan
+type HTTPBackupPlanSpec struct {
+	BackupPlanSpec `json:",inline"`
+
+	// URL of the endpoint exporting the state including the scheme.
+	// Environment variables will be evaluated before usage.
+	URL string `json:"url"`
+
+	// +optional
+	// +kubebuilder:validation:Enum=GET;POST
+	// Method of the request. Defaults to GET.
+	Method string `json:"method,omitempty"`
+
+	// +optional
+	// Headers added to the request
+	Headers map[string]string `json:"headers,omitempty"`
+
+	// +optional
+	// Body of the request
+	Body string `json:"body,omitempty"`
+
+	// +optional
+	// Username for basic authentication. Falls back to HTTP_USERNAME.
+	Username string `json:"username,omitempty"`
+
+	// +optional
+	// Password for basic authentication. Falls back to HTTP_PASSWORD.
+	Password string `json:"password,omitempty"`
+
+	// +optional
+	// Token for bearer authentication. Falls back to HTTP_BEARER_TOKEN.
+	BearerToken string `json:"bearerToken,omitempty"`
+
+	// +optional
+	// Path of the CA certificate to verify the endpoint with, e.g. mounted
+	// via volumeMounts
+	CACert string `json:"caCert,omitempty"`
+
+	// +optional
+	// Extension of the stored backups, e.g. "json". Defaults to "out".
+	Extension string `json:"extension,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// HTTPBackupPlan is the Schema for the httpbackupplans API
+type HTTPBackupPlan struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   HTTPBackupPlanSpec `json:"spec,omitempty"`
+	Status BackupPlanStatus   `json:"status,omitempty"`
+}
+
+func (p *HTTPBackupPlan) GetTypeMeta() *metav1.TypeMeta {
+	return &p.TypeMeta
+}
+
+func (p *HTTPBackupPlan) GetObjectMeta() *metav1.ObjectMeta {
+	return &p.ObjectMeta
+}
+
+func (p *HTTPBackupPlan) GetSpec() *BackupPlanSpec {
+	return &p.Spec.BackupPlanSpec
+}
+
+func (p *HTTPBackupPlan) GetStatus() *BackupPlanStatus {
+	return &p.Status
+}
+
+func (p *HTTPBackupPlan) GetKind() string {
+	return HTTPBackupPlanKind
+}
+
+func (p *HTTPBackupPlan) GetCmd() string {
+	return HTTPBackupPlanWorkerCommand
+}
+
+func (p *HTTPBackupPlan) GetSecretData() ([]byte, error) {
+	reduced := HTTPBackupPlan{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: p.Namespace,
+			Name:      p.Name,
+		},
+		Spec: p.Spec,
+	}
+	return json.Marshal(&reduced)
+}
+
+func (p *HTTPBackupPlan) New() BackupPlan {
+	return &HTTPBackupPlan{}
+}
+
+// +kubebuilder:object:root=true
+
+// HTTPBackupPlanList contains a list of HTTPBackupPlan
+type HTTPBackupPlanList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []HTTPBackupPlan `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&HTTPBackupPlan{}, &HTTPBackupPlanList{})
+}
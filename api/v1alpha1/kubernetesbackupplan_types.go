@@ -0,0 +1,146 @@
+/*
+Copyright 2020 Backup Operator Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"encoding/json"
+	"strings"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const KubernetesBackupPlanKind = "KubernetesBackupPlan"
+const KubernetesBackupPlanWorkerCommand = "kubernetes"
+
+// KubernetesBackupPlanSpec defines the desired state of KubernetesBackupPlan
+type KubernetesBackupPlanSpec struct {
+	BackupPlanSpec `json:",inline"`
+
+	// +optional
+	// Namespaces to backup. Defaults to the namespace of the plan. Backing up
+	// other namespaces requires a ServiceAccount with sufficient permissions.
+	Namespaces []string `json:"namespaces,omitempty"`
+
+	// +optional
+	// Label selector in the format of kubectl to filter objects
+	LabelSelector string `json:"labelSelector,omitempty"`
+
+	// +optional
+	// Resources to backup in the format of <plural>[.<group>], e.g.
+	// "configmaps" or "deployments.apps". Defaults to all listable resources.
+	Resources []string `json:"resources,omitempty"`
+
+	// +optional
+	// Name of the ServiceAccount used by the worker. If not set, a
+	// ServiceAccount with read access to the namespace of the plan will be
+	// created.
+	ServiceAccountName string `json:"serviceAccountName,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// KubernetesBackupPlan is the Schema for the kubernetesbackupplans API
+type KubernetesBackupPlan struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   KubernetesBackupPlanSpec `json:"spec,omitempty"`
+	Status BackupPlanStatus         `json:"status,omitempty"`
+}
+
+func (p *KubernetesBackupPlan) GetTypeMeta() *metav1.TypeMeta {
+	return &p.TypeMeta
+}
+
+func (p *KubernetesBackupPlan) GetObjectMeta() *metav1.ObjectMeta {
+	return &p.ObjectMeta
+}
+
+func (p *KubernetesBackupPlan) GetSpec() *BackupPlanSpec {
+	return &p.Spec.BackupPlanSpec
+}
+
+func (p *KubernetesBackupPlan) GetStatus() *BackupPlanStatus {
+	return &p.Status
+}
+
+func (p *KubernetesBackupPlan) GetKind() string {
+	return KubernetesBackupPlanKind
+}
+
+func (p *KubernetesBackupPlan) GetCmd() string {
+	return KubernetesBackupPlanWorkerCommand
+}
+
+func (p *KubernetesBackupPlan) GetWorkerServiceAccountName() string {
+	return p.Spec.ServiceAccountName
+}
+
+func (p *KubernetesBackupPlan) GetWorkerPolicyRules() []rbacv1.PolicyRule {
+	verbs := []string{"get", "list"}
+	if len(p.Spec.Resources) == 0 {
+		return []rbacv1.PolicyRule{{
+			APIGroups: []string{"*"},
+			Resources: []string{"*"},
+			Verbs:     verbs,
+		}}
+	}
+	rules := make([]rbacv1.PolicyRule, 0, len(p.Spec.Resources))
+	for _, resource := range p.Spec.Resources {
+		// resources without group could be core or any group, because the
+		// worker matches them by their plural name only
+		group := "*"
+		if i := strings.Index(resource, "."); i >= 0 {
+			resource, group = resource[:i], resource[i+1:]
+		}
+		rules = append(rules, rbacv1.PolicyRule{
+			APIGroups: []string{group},
+			Resources: []string{resource},
+			Verbs:     verbs,
+		})
+	}
+	return rules
+}
+
+func (p *KubernetesBackupPlan) GetSecretData() ([]byte, error) {
+	reduced := KubernetesBackupPlan{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: p.Namespace,
+			Name:      p.Name,
+		},
+		Spec: p.Spec,
+	}
+	return json.Marshal(&reduced)
+}
+
+func (p *KubernetesBackupPlan) New() BackupPlan {
+	return &KubernetesBackupPlan{}
+}
+
+// +kubebuilder:object:root=true
+
+// KubernetesBackupPlanList contains a list of KubernetesBackupPlan
+type KubernetesBackupPlanList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []KubernetesBackupPlan `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&KubernetesBackupPlan{}, &KubernetesBackupPlanList{})
+}
@@ -24,6 +24,8 @@ import (
 
 const MongoDBBackupPlanKind = "MongoDBBackupPlan"
 const MongoDBBackupPlanWorkerCommand = "mongodb"
+const MongoDBBackupPlanOplogWorkerName = "oplog"
+const MongoDBBackupPlanOplogWorkerCommand = "mongodb-oplog"
 
 // MongoDBBackupPlanSpec defines the desired state of MongoDBBackupPlan
 type MongoDBBackupPlanSpec struct {
@@ -32,6 +34,116 @@ type MongoDBBackupPlanSpec struct {
 	// Fully qualifying MongoDB URI connection string. Environment variables
 	// will be evaluated before usage.
 	URI string `json:"uri"`
+
+	// +optional
+	// Database to backup. If empty, all databases are backed up.
+	Database string `json:"database,omitempty"`
+
+	// +optional
+	// Collections of the database to backup. Supports the wildcard "*",
+	// e.g. "orders_*". If empty, all collections are backed up.
+	IncludeCollections []string `json:"includeCollections,omitempty"`
+
+	// +optional
+	// Collections of the database to exclude. Supports the wildcard "*".
+	ExcludeCollections []string `json:"excludeCollections,omitempty"`
+
+	// +optional
+	// Namespaces in the format <database>.<collection> to backup. Supports
+	// the wildcard "*", e.g. "tenant_*.orders". If empty, all namespaces are
+	// backed up. As mongodump excludes collections by name, namespaces of
+	// multiple databases can only be filtered, if collections with the same
+	// name are either included or excluded in all databases.
+	IncludeNamespaces []string `json:"includeNamespaces,omitempty"`
+
+	// +optional
+	// Namespaces in the format <database>.<collection> to exclude. Supports
+	// the wildcard "*", e.g. "*.audit_*".
+	ExcludeNamespaces []string `json:"excludeNamespaces,omitempty"`
+
+	// +optional
+	// +kubebuilder:validation:Enum=primary;primaryPreferred;secondary;secondaryPreferred;nearest
+	// Read preference of the dump, e.g. secondary to reduce the load of the
+	// primary. If empty, the read preference of the URI is used.
+	ReadPreference string `json:"readPreference,omitempty"`
+
+	// +optional
+	// Tag sets of the read preference in order of preference, e.g.
+	// [{"use": "backup"}, {}]. Requires readPreference to be set.
+	ReadPreferenceTags []map[string]string `json:"readPreferenceTags,omitempty"`
+
+	// +optional
+	// Refuse to backup, if the primary would be read, e.g. because no
+	// secondary matching the read preference is available.
+	RequireSecondary bool `json:"requireSecondary,omitempty"`
+
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	// Number of collections to dump in parallel. Defaults to 4.
+	NumParallelCollections int64 `json:"numParallelCollections,omitempty"`
+
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	// Number of concurrent insert operations per collection, when restoring
+	// backups of the plan. If empty, the default of mongorestore is used.
+	NumInsertionWorkersPerCollection int64 `json:"numInsertionWorkersPerCollection,omitempty"`
+
+	// +optional
+	// +kubebuilder:validation:Enum=database;collection
+	// Store a separate archive per database or collection grouped in a
+	// directory per backup, so single collections can be restored without
+	// downloading the whole backup.
+	SplitBy string `json:"splitBy,omitempty"`
+
+	// +optional
+	// Oplog enables point-in-time recovery. Backups are created as consistent
+	// dumps including the oplog and the oplog is additionally shipped as
+	// slices in between. Requires a replica set and can not be combined with
+	// namespace filters.
+	Oplog *MongoDBOplog `json:"oplog,omitempty"`
+
+	// +optional
+	// TLS secures the connection, if set. The referenced files are usually
+	// mounted from secrets using volumes and volumeMounts.
+	TLS *MongoDBTLS `json:"tls,omitempty"`
+
+	// +optional
+	// +kubebuilder:validation:Enum=SCRAM-SHA-1;SCRAM-SHA-256;MONGODB-X509
+	// Authentication mechanism. MONGODB-X509 authenticates with the client
+	// certificate of tls. If empty, the mechanism of the URI is used.
+	AuthenticationMechanism string `json:"authenticationMechanism,omitempty"`
+}
+
+// MongoDBTLS references the files used to secure the connection
+type MongoDBTLS struct {
+	// +optional
+	// Path to the CA bundle used to verify the server. If empty, the system
+	// CAs are used.
+	CAFile string `json:"caFile,omitempty"`
+
+	// +optional
+	// Path to the client certificate, which may contain the key as well
+	CertFile string `json:"certFile,omitempty"`
+
+	// +optional
+	// Path to the key of the client certificate, if stored separately
+	KeyFile string `json:"keyFile,omitempty"`
+
+	// +optional
+	// Password of an encrypted key. Falls back to MONGODB_TLS_KEY_PASSWORD.
+	// Not supported in combination with oplog.
+	KeyPassword string `json:"keyPassword,omitempty"`
+
+	// +optional
+	// Skip the verification of the server certificate and hostname
+	InsecureSkipVerify bool `json:"insecureSkipVerify,omitempty"`
+}
+
+// MongoDBOplog configures the continuous capture of the oplog
+type MongoDBOplog struct {
+	// Schedule in Cron format of shipping the oplog written since the last
+	// slice or backup. Has to be more frequent than the oplog window.
+	Schedule string `json:"schedule"`
 }
 
 // +kubebuilder:object:root=true
@@ -80,6 +192,18 @@ func (p *MongoDBBackupPlan) GetSecretData() ([]byte, error) {
 	return json.Marshal(&reduced)
 }
 
+func (p *MongoDBBackupPlan) GetAdditionalWorkers() []AdditionalWorker {
+	schedule := ""
+	if p.Spec.Oplog != nil {
+		schedule = p.Spec.Oplog.Schedule
+	}
+	return []AdditionalWorker{{
+		Name:     MongoDBBackupPlanOplogWorkerName,
+		Schedule: schedule,
+		Cmd:      MongoDBBackupPlanOplogWorkerCommand,
+	}}
+}
+
 func (p *MongoDBBackupPlan) New() BackupPlan {
 	return &MongoDBBackupPlan{}
 }
@@ -0,0 +1,110 @@
+/*
+Copyright 2020 Backup Operator Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"encoding/json"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const MySQLBackupPlanKind = "MySQLBackupPlan"
+const MySQLBackupPlanWorkerCommand = "mysql"
+
+// MySQLBackupPlanSpec defines the desired state of MySQLBackupPlan
+type MySQLBackupPlanSpec struct {
+	BackupPlanSpec `json:",inline"`
+
+	// Address of MySQL or MariaDB in the format host:port. Environment
+	// variables will be evaluated before usage.
+	Address string `json:"address"`
+
+	// +optional
+	// Username to authenticate with MySQL
+	Username string `json:"username,omitempty"`
+
+	// +optional
+	// Password to authenticate with MySQL
+	Password string `json:"password,omitempty"`
+
+	// +optional
+	// Databases to dump. If none are provided all databases will be dumped.
+	Databases []string `json:"databases,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// MySQLBackupPlan is the Schema for the mysqlbackupplans API
+type MySQLBackupPlan struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   MySQLBackupPlanSpec `json:"spec,omitempty"`
+	Status BackupPlanStatus    `json:"status,omitempty"`
+}
+
+func (p *MySQLBackupPlan) GetTypeMeta() *metav1.TypeMeta {
+	return &p.TypeMeta
+}
+
+func (p *MySQLBackupPlan) GetObjectMeta() *metav1.ObjectMeta {
+	return &p.ObjectMeta
+}
+
+func (p *MySQLBackupPlan) GetSpec() *BackupPlanSpec {
+	return &p.Spec.BackupPlanSpec
+}
+
+func (p *MySQLBackupPlan) GetStatus() *BackupPlanStatus {
+	return &p.Status
+}
+
+func (p *MySQLBackupPlan) GetKind() string {
+	return MySQLBackupPlanKind
+}
+
+func (p *MySQLBackupPlan) GetCmd() string {
+	return MySQLBackupPlanWorkerCommand
+}
+
+func (p *MySQLBackupPlan) GetSecretData() ([]byte, error) {
+	reduced := MySQLBackupPlan{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: p.Namespace,
+			Name:      p.Name,
+		},
+		Spec: p.Spec,
+	}
+	return json.Marshal(&reduced)
+}
+
+func (p *MySQLBackupPlan) New() BackupPlan {
+	return &MySQLBackupPlan{}
+}
+
+// +kubebuilder:object:root=true
+
+// MySQLBackupPlanList contains a list of MySQLBackupPlan
+type MySQLBackupPlanList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []MySQLBackupPlan `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&MySQLBackupPlan{}, &MySQLBackupPlanList{})
+}
@@ -0,0 +1,98 @@
+/*
+Copyright 2020 Backup Operator Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"encoding/json"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const PostgreSQLBackupPlanKind = "PostgreSQLBackupPlan"
+const PostgreSQLBackupPlanWorkerCommand = "postgresql"
+
+// PostgreSQLBackupPlanSpec defines the desired state of PostgreSQLBackupPlan
+type PostgreSQLBackupPlanSpec struct {
+	BackupPlanSpec `json:",inline"`
+
+	// Fully qualifying PostgreSQL connection URI including the database to
+	// dump. Environment variables will be evaluated before usage.
+	URI string `json:"uri"`
+}
+
+// +kubebuilder:object:root=true
+
+// PostgreSQLBackupPlan is the Schema for the postgresqlbackupplans API
+type PostgreSQLBackupPlan struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   PostgreSQLBackupPlanSpec `json:"spec,omitempty"`
+	Status BackupPlanStatus         `json:"status,omitempty"`
+}
+
+func (p *PostgreSQLBackupPlan) GetTypeMeta() *metav1.TypeMeta {
+	return &p.TypeMeta
+}
+
+func (p *PostgreSQLBackupPlan) GetObjectMeta() *metav1.ObjectMeta {
+	return &p.ObjectMeta
+}
+
+func (p *PostgreSQLBackupPlan) GetSpec() *BackupPlanSpec {
+	return &p.Spec.BackupPlanSpec
+}
+
+func (p *PostgreSQLBackupPlan) GetStatus() *BackupPlanStatus {
+	return &p.Status
+}
+
+func (p *PostgreSQLBackupPlan) GetKind() string {
+	return PostgreSQLBackupPlanKind
+}
+
+func (p *PostgreSQLBackupPlan) GetCmd() string {
+	return PostgreSQLBackupPlanWorkerCommand
+}
+
+func (p *PostgreSQLBackupPlan) GetSecretData() ([]byte, error) {
+	reduced := PostgreSQLBackupPlan{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: p.Namespace,
+			Name:      p.Name,
+		},
+		Spec: p.Spec,
+	}
+	return json.Marshal(&reduced)
+}
+
+func (p *PostgreSQLBackupPlan) New() BackupPlan {
+	return &PostgreSQLBackupPlan{}
+}
+
+// +kubebuilder:object:root=true
+
+// PostgreSQLBackupPlanList contains a list of PostgreSQLBackupPlan
+type PostgreSQLBackupPlanList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []PostgreSQLBackupPlan `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&PostgreSQLBackupPlan{}, &PostgreSQLBackupPlanList{})
+}
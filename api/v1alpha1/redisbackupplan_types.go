@@ -0,0 +1,106 @@
+/*
+Copyright 2020 Backup Operator Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"encoding/json"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const RedisBackupPlanKind = "RedisBackupPlan"
+const RedisBackupPlanWorkerCommand = "redis"
+
+// RedisBackupPlanSpec defines the desired state of RedisBackupPlan
+type RedisBackupPlanSpec struct {
+	BackupPlanSpec `json:",inline"`
+
+	// Address of Redis in the form host:port. Environment variables
+	// will be evaluated before usage.
+	Address string `json:"address"`
+
+	// +optional
+	// Username to authenticate with redis (requires ACLs of Redis 6)
+	Username string `json:"username,omitempty"`
+
+	// +optional
+	// Password to authenticate with redis
+	Password string `json:"password,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// RedisBackupPlan is the Schema for the redisbackupplans API
+type RedisBackupPlan struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   RedisBackupPlanSpec `json:"spec,omitempty"`
+	Status BackupPlanStatus    `json:"status,omitempty"`
+}
+
+func (p *RedisBackupPlan) GetTypeMeta() *metav1.TypeMeta {
+	return &p.TypeMeta
+}
+
+func (p *RedisBackupPlan) GetObjectMeta() *metav1.ObjectMeta {
+	return &p.ObjectMeta
+}
+
+func (p *RedisBackupPlan) GetSpec() *BackupPlanSpec {
+	return &p.Spec.BackupPlanSpec
+}
+
+func (p *RedisBackupPlan) GetStatus() *BackupPlanStatus {
+	return &p.Status
+}
+
+func (p *RedisBackupPlan) GetKind() string {
+	return RedisBackupPlanKind
+}
+
+func (p *RedisBackupPlan) GetCmd() string {
+	return RedisBackupPlanWorkerCommand
+}
+
+func (p *RedisBackupPlan) GetSecretData() ([]byte, error) {
+	reduced := RedisBackupPlan{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: p.Namespace,
+			Name:      p.Name,
+		},
+		Spec: p.Spec,
+	}
+	return json.Marshal(&reduced)
+}
+
+func (p *RedisBackupPlan) New() BackupPlan {
+	return &RedisBackupPlan{}
+}
+
+// +kubebuilder:object:root=true
+
+// RedisBackupPlanList contains a list of RedisBackupPlan
+type RedisBackupPlanList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []RedisBackupPlan `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&RedisBackupPlan{}, &RedisBackupPlanList{})
+}
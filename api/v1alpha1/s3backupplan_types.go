@@ -0,0 +1,108 @@
+/*
+Copyright 2020 Backup Operator Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"encoding/json"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const S3BackupPlanKind = "S3BackupPlan"
+const S3BackupPlanWorkerCommand = "s3"
+
+// S3BackupPlanSpec defines the desired state of S3BackupPlan
+type S3BackupPlanSpec struct {
+	BackupPlanSpec `json:",inline"`
+
+	// S3 compatible storage to backup. Credentials fall back to the
+	// environment variables prefixed with SOURCE_, e.g.
+	// SOURCE_S3_ACCESS_KEY_ID.
+	Source S3 `json:"source"`
+
+	// +optional
+	// Prefix of the objects to backup. Defaults to the whole bucket.
+	Prefix string `json:"prefix,omitempty"`
+
+	// +optional
+	// Skip objects, which ETag did not change since the last snapshot. Skipped
+	// objects are referenced in the manifest of the snapshot instead.
+	Incremental bool `json:"incremental,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// S3BackupPlan is the Schema for the s3backupplans API
+type S3BackupPlan struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   S3BackupPlanSpec `json:"spec,omitempty"`
+	Status BackupPlanStatus `json:"status,omitempty"`
+}
+
+func (p *S3BackupPlan) GetTypeMeta() *metav1.TypeMeta {
+	return &p.TypeMeta
+}
+
+func (p *S3BackupPlan) GetObjectMeta() *metav1.ObjectMeta {
+	return &p.ObjectMeta
+}
+
+func (p *S3BackupPlan) GetSpec() *BackupPlanSpec {
+	return &p.Spec.BackupPlanSpec
+}
+
+func (p *S3BackupPlan) GetStatus() *BackupPlanStatus {
+	return &p.Status
+}
+
+func (p *S3BackupPlan) GetKind() string {
+	return S3BackupPlanKind
+}
+
+func (p *S3BackupPlan) GetCmd() string {
+	return S3BackupPlanWorkerCommand
+}
+
+func (p *S3BackupPlan) GetSecretData() ([]byte, error) {
+	reduced := S3BackupPlan{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: p.Namespace,
+			Name:      p.Name,
+		},
+		Spec: p.Spec,
+	}
+	return json.Marshal(&reduced)
+}
+
+func (p *S3BackupPlan) New() BackupPlan {
+	return &S3BackupPlan{}
+}
+
+// +kubebuilder:object:root=true
+
+// S3BackupPlanList contains a list of S3BackupPlan
+type S3BackupPlanList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []S3BackupPlan `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&S3BackupPlan{}, &S3BackupPlanList{})
+}
@@ -0,0 +1,122 @@
+/*
+Copyright 2020 Backup Operator Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"encoding/json"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const VaultBackupPlanKind = "VaultBackupPlan"
+const VaultBackupPlanWorkerCommand = "vault"
+
+// VaultBackupPlanSpec defines the desired state of VaultBackupPlan
+type VaultBackupPlanSpec struct {
+	BackupPlanSpec `json:",inline"`
+
+	// Address of Vault including the scheme. Environment variables
+	// will be evaluated before usage.
+	Address string `json:"address"`
+
+	// +optional
+	// Token to authenticate with vault. Either token or kubernetes auth is
+	// required.
+	Token string `json:"token,omitempty"`
+
+	// +optional
+	// Kubernetes auth method to authenticate with vault using the service
+	// account of the worker
+	Kubernetes *VaultKubernetesAuth `json:"kubernetes,omitempty"`
+
+	// +optional
+	// Path of the CA certificate to verify vault with, e.g. mounted via
+	// volumeMounts
+	CACert string `json:"caCert,omitempty"`
+}
+
+type VaultKubernetesAuth struct {
+	// Role to login with
+	Role string `json:"role"`
+
+	// +optional
+	// Path the auth method is mounted at, defaults to kubernetes
+	MountPath string `json:"mountPath,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// VaultBackupPlan is the Schema for the vaultbackupplans API
+type VaultBackupPlan struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   VaultBackupPlanSpec `json:"spec,omitempty"`
+	Status BackupPlanStatus    `json:"status,omitempty"`
+}
+
+func (p *VaultBackupPlan) GetTypeMeta() *metav1.TypeMeta {
+	return &p.TypeMeta
+}
+
+func (p *VaultBackupPlan) GetObjectMeta() *metav1.ObjectMeta {
+	return &p.ObjectMeta
+}
+
+func (p *VaultBackupPlan) GetSpec() *BackupPlanSpec {
+	return &p.Spec.BackupPlanSpec
+}
+
+func (p *VaultBackupPlan) GetStatus() *BackupPlanStatus {
+	return &p.Status
+}
+
+func (p *VaultBackupPlan) GetKind() string {
+	return VaultBackupPlanKind
+}
+
+func (p *VaultBackupPlan) GetCmd() string {
+	return VaultBackupPlanWorkerCommand
+}
+
+func (p *VaultBackupPlan) GetSecretData() ([]byte, error) {
+	reduced := VaultBackupPlan{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: p.Namespace,
+			Name:      p.Name,
+		},
+		Spec: p.Spec,
+	}
+	return json.Marshal(&reduced)
+}
+
+func (p *VaultBackupPlan) New() BackupPlan {
+	return &VaultBackupPlan{}
+}
+
+// +kubebuilder:object:root=true
+
+// VaultBackupPlanList contains a list of VaultBackupPlan
+type VaultBackupPlanList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []VaultBackupPlan `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&VaultBackupPlan{}, &VaultBackupPlanList{})
+}
@@ -0,0 +1,157 @@
+/*
+Copyright 2020 Backup Operator Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"encoding/json"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const VolumeBackupPlanKind = "VolumeBackupPlan"
+const VolumeBackupPlanWorkerCommand = "volume"
+const VolumeBackupPlanVolumeName = "volume"
+const VolumeBackupPlanMountPath = "/mnt/volume"
+
+// VolumeBackupPlanSpec defines the desired state of VolumeBackupPlan
+type VolumeBackupPlanSpec struct {
+	BackupPlanSpec `json:",inline"`
+
+	// Name of the PersistentVolumeClaim to backup, which will be mounted
+	// read-only into the worker
+	ClaimName string `json:"claimName"`
+
+	// +optional
+	// Path within the volume to backup. Defaults to the root of the volume.
+	SubPath string `json:"subPath,omitempty"`
+
+	// +optional
+	// Glob patterns of paths to include. If empty, everything is included.
+	Include []string `json:"include,omitempty"`
+
+	// +optional
+	// Glob patterns of paths to exclude
+	Exclude []string `json:"exclude,omitempty"`
+
+	// +optional
+	// User the worker runs as. The worker image runs as user 65532 by
+	// default, so use 0 to be able to read all files of the volume regardless
+	// of their owner.
+	RunAsUser *int64 `json:"runAsUser,omitempty"`
+
+	// +optional
+	// Supplemental group of the worker, which can be used to read files of
+	// the volume owned by this group
+	FSGroup *int64 `json:"fsGroup,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// VolumeBackupPlan is the Schema for the volumebackupplans API
+type VolumeBackupPlan struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   VolumeBackupPlanSpec `json:"spec,omitempty"`
+	Status BackupPlanStatus     `json:"status,omitempty"`
+}
+
+func (p *VolumeBackupPlan) GetTypeMeta() *metav1.TypeMeta {
+	return &p.TypeMeta
+}
+
+func (p *VolumeBackupPlan) GetObjectMeta() *metav1.ObjectMeta {
+	return &p.ObjectMeta
+}
+
+func (p *VolumeBackupPlan) GetSpec() *BackupPlanSpec {
+	return &p.Spec.BackupPlanSpec
+}
+
+func (p *VolumeBackupPlan) GetStatus() *BackupPlanStatus {
+	return &p.Status
+}
+
+func (p *VolumeBackupPlan) GetKind() string {
+	return VolumeBackupPlanKind
+}
+
+func (p *VolumeBackupPlan) GetCmd() string {
+	return VolumeBackupPlanWorkerCommand
+}
+
+func (p *VolumeBackupPlan) GetWorkerVolumes() ([]corev1.Volume, []corev1.VolumeMount) {
+	volumes := []corev1.Volume{
+		{
+			Name: VolumeBackupPlanVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+					ClaimName: p.Spec.ClaimName,
+					ReadOnly:  true,
+				},
+			},
+		},
+	}
+	volumeMounts := []corev1.VolumeMount{
+		{
+			Name:      VolumeBackupPlanVolumeName,
+			MountPath: VolumeBackupPlanMountPath,
+			SubPath:   p.Spec.SubPath,
+			ReadOnly:  true,
+		},
+	}
+	return volumes, volumeMounts
+}
+
+func (p *VolumeBackupPlan) GetWorkerSecurityContext() *corev1.PodSecurityContext {
+	if p.Spec.RunAsUser == nil && p.Spec.FSGroup == nil {
+		return nil
+	}
+	return &corev1.PodSecurityContext{
+		RunAsUser: p.Spec.RunAsUser,
+		FSGroup:   p.Spec.FSGroup,
+	}
+}
+
+func (p *VolumeBackupPlan) GetSecretData() ([]byte, error) {
+	reduced := VolumeBackupPlan{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: p.Namespace,
+			Name:      p.Name,
+		},
+		Spec: p.Spec,
+	}
+	return json.Marshal(&reduced)
+}
+
+func (p *VolumeBackupPlan) New() BackupPlan {
+	return &VolumeBackupPlan{}
+}
+
+// +kubebuilder:object:root=true
+
+// VolumeBackupPlanList contains a list of VolumeBackupPlan
+type VolumeBackupPlanList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []VolumeBackupPlan `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&VolumeBackupPlan{}, &VolumeBackupPlanList{})
+}
@@ -26,6 +26,21 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Azure) DeepCopyInto(out *Azure) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Azure.
+func (in *Azure) DeepCopy() *Azure {
+	if in == nil {
+		return nil
+	}
+	out := new(Azure)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BackupPlanSpec) DeepCopyInto(out *BackupPlanSpec) {
 	*out = *in
@@ -160,6 +175,16 @@ func (in *ConsulBackupPlanList) DeepCopyObject() runtime.Object {
 func (in *ConsulBackupPlanSpec) DeepCopyInto(out *ConsulBackupPlanSpec) {
 	*out = *in
 	in.BackupPlanSpec.DeepCopyInto(&out.BackupPlanSpec)
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(ConsulTLS)
+		**out = **in
+	}
+	if in.Prefixes != nil {
+		in, out := &in.Prefixes, &out.Prefixes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConsulBackupPlanSpec.
@@ -172,6 +197,21 @@ func (in *ConsulBackupPlanSpec) DeepCopy() *ConsulBackupPlanSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConsulTLS) DeepCopyInto(out *ConsulTLS) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConsulTLS.
+func (in *ConsulTLS) DeepCopy() *ConsulTLS {
+	if in == nil {
+		return nil
+	}
+	out := new(ConsulTLS)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Destination) DeepCopyInto(out *Destination) {
 	*out = *in
@@ -180,6 +220,31 @@ func (in *Destination) DeepCopyInto(out *Destination) {
 		*out = new(S3)
 		**out = **in
 	}
+	if in.GCS != nil {
+		in, out := &in.GCS, &out.GCS
+		*out = new(GCS)
+		**out = **in
+	}
+	if in.Azure != nil {
+		in, out := &in.Azure, &out.Azure
+		*out = new(Azure)
+		**out = **in
+	}
+	if in.SFTP != nil {
+		in, out := &in.SFTP, &out.SFTP
+		*out = new(SFTP)
+		**out = **in
+	}
+	if in.Volume != nil {
+		in, out := &in.Volume, &out.Volume
+		*out = new(Volume)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.WebDAV != nil {
+		in, out := &in.WebDAV, &out.WebDAV
+		*out = new(WebDAV)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Destination.
@@ -193,7 +258,7 @@ func (in *Destination) DeepCopy() *Destination {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MongoDBBackupPlan) DeepCopyInto(out *MongoDBBackupPlan) {
+func (in *EtcdBackupPlan) DeepCopyInto(out *EtcdBackupPlan) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -201,18 +266,18 @@ func (in *MongoDBBackupPlan) DeepCopyInto(out *MongoDBBackupPlan) {
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MongoDBBackupPlan.
-func (in *MongoDBBackupPlan) DeepCopy() *MongoDBBackupPlan {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EtcdBackupPlan.
+func (in *EtcdBackupPlan) DeepCopy() *EtcdBackupPlan {
 	if in == nil {
 		return nil
 	}
-	out := new(MongoDBBackupPlan)
+	out := new(EtcdBackupPlan)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *MongoDBBackupPlan) DeepCopyObject() runtime.Object {
+func (in *EtcdBackupPlan) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -220,31 +285,31 @@ func (in *MongoDBBackupPlan) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MongoDBBackupPlanList) DeepCopyInto(out *MongoDBBackupPlanList) {
+func (in *EtcdBackupPlanList) DeepCopyInto(out *EtcdBackupPlanList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]MongoDBBackupPlan, len(*in))
+		*out = make([]EtcdBackupPlan, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MongoDBBackupPlanList.
-func (in *MongoDBBackupPlanList) DeepCopy() *MongoDBBackupPlanList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EtcdBackupPlanList.
+func (in *EtcdBackupPlanList) DeepCopy() *EtcdBackupPlanList {
 	if in == nil {
 		return nil
 	}
-	out := new(MongoDBBackupPlanList)
+	out := new(EtcdBackupPlanList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *MongoDBBackupPlanList) DeepCopyObject() runtime.Object {
+func (in *EtcdBackupPlanList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -252,47 +317,1028 @@ func (in *MongoDBBackupPlanList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MongoDBBackupPlanSpec) DeepCopyInto(out *MongoDBBackupPlanSpec) {
+func (in *EtcdBackupPlanSpec) DeepCopyInto(out *EtcdBackupPlanSpec) {
 	*out = *in
 	in.BackupPlanSpec.DeepCopyInto(&out.BackupPlanSpec)
+	if in.Endpoints != nil {
+		in, out := &in.Endpoints, &out.Endpoints
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(EtcdTLS)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MongoDBBackupPlanSpec.
-func (in *MongoDBBackupPlanSpec) DeepCopy() *MongoDBBackupPlanSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EtcdBackupPlanSpec.
+func (in *EtcdBackupPlanSpec) DeepCopy() *EtcdBackupPlanSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(MongoDBBackupPlanSpec)
+	out := new(EtcdBackupPlanSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Pushgateway) DeepCopyInto(out *Pushgateway) {
+func (in *EtcdTLS) DeepCopyInto(out *EtcdTLS) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Pushgateway.
-func (in *Pushgateway) DeepCopy() *Pushgateway {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EtcdTLS.
+func (in *EtcdTLS) DeepCopy() *EtcdTLS {
 	if in == nil {
 		return nil
 	}
-	out := new(Pushgateway)
+	out := new(EtcdTLS)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *S3) DeepCopyInto(out *S3) {
+func (in *ExecBackupPlan) DeepCopyInto(out *ExecBackupPlan) {
 	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new S3.
-func (in *S3) DeepCopy() *S3 {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExecBackupPlan.
+func (in *ExecBackupPlan) DeepCopy() *ExecBackupPlan {
 	if in == nil {
 		return nil
 	}
-	out := new(S3)
+	out := new(ExecBackupPlan)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ExecBackupPlan) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExecBackupPlanList) DeepCopyInto(out *ExecBackupPlanList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ExecBackupPlan, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExecBackupPlanList.
+func (in *ExecBackupPlanList) DeepCopy() *ExecBackupPlanList {
+	if in == nil {
+		return nil
+	}
+	out := new(ExecBackupPlanList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ExecBackupPlanList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExecBackupPlanSpec) DeepCopyInto(out *ExecBackupPlanSpec) {
+	*out = *in
+	in.BackupPlanSpec.DeepCopyInto(&out.BackupPlanSpec)
+	if in.Command != nil {
+		in, out := &in.Command, &out.Command
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExecBackupPlanSpec.
+func (in *ExecBackupPlanSpec) DeepCopy() *ExecBackupPlanSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ExecBackupPlanSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GCS) DeepCopyInto(out *GCS) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCS.
+func (in *GCS) DeepCopy() *GCS {
+	if in == nil {
+		return nil
+	}
+	out := new(GCS)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPBackupPlan) DeepCopyInto(out *HTTPBackupPlan) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPBackupPlan.
+func (in *HTTPBackupPlan) DeepCopy() *HTTPBackupPlan {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPBackupPlan)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *HTTPBackupPlan) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPBackupPlanList) DeepCopyInto(out *HTTPBackupPlanList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]HTTPBackupPlan, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPBackupPlanList.
+func (in *HTTPBackupPlanList) DeepCopy() *HTTPBackupPlanList {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPBackupPlanList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *HTTPBackupPlanList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPBackupPlanSpec) DeepCopyInto(out *HTTPBackupPlanSpec) {
+	*out = *in
+	in.BackupPlanSpec.DeepCopyInto(&out.BackupPlanSpec)
+	if in.Headers != nil {
+		in, out := &in.Headers, &out.Headers
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPBackupPlanSpec.
+func (in *HTTPBackupPlanSpec) DeepCopy() *HTTPBackupPlanSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPBackupPlanSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubernetesBackupPlan) DeepCopyInto(out *KubernetesBackupPlan) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubernetesBackupPlan.
+func (in *KubernetesBackupPlan) DeepCopy() *KubernetesBackupPlan {
+	if in == nil {
+		return nil
+	}
+	out := new(KubernetesBackupPlan)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *KubernetesBackupPlan) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubernetesBackupPlanList) DeepCopyInto(out *KubernetesBackupPlanList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]KubernetesBackupPlan, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubernetesBackupPlanList.
+func (in *KubernetesBackupPlanList) DeepCopy() *KubernetesBackupPlanList {
+	if in == nil {
+		return nil
+	}
+	out := new(KubernetesBackupPlanList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *KubernetesBackupPlanList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubernetesBackupPlanSpec) DeepCopyInto(out *KubernetesBackupPlanSpec) {
+	*out = *in
+	in.BackupPlanSpec.DeepCopyInto(&out.BackupPlanSpec)
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubernetesBackupPlanSpec.
+func (in *KubernetesBackupPlanSpec) DeepCopy() *KubernetesBackupPlanSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KubernetesBackupPlanSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MongoDBBackupPlan) DeepCopyInto(out *MongoDBBackupPlan) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MongoDBBackupPlan.
+func (in *MongoDBBackupPlan) DeepCopy() *MongoDBBackupPlan {
+	if in == nil {
+		return nil
+	}
+	out := new(MongoDBBackupPlan)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *MongoDBBackupPlan) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MongoDBBackupPlanList) DeepCopyInto(out *MongoDBBackupPlanList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]MongoDBBackupPlan, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MongoDBBackupPlanList.
+func (in *MongoDBBackupPlanList) DeepCopy() *MongoDBBackupPlanList {
+	if in == nil {
+		return nil
+	}
+	out := new(MongoDBBackupPlanList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *MongoDBBackupPlanList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MongoDBBackupPlanSpec) DeepCopyInto(out *MongoDBBackupPlanSpec) {
+	*out = *in
+	in.BackupPlanSpec.DeepCopyInto(&out.BackupPlanSpec)
+	if in.IncludeCollections != nil {
+		in, out := &in.IncludeCollections, &out.IncludeCollections
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExcludeCollections != nil {
+		in, out := &in.ExcludeCollections, &out.ExcludeCollections
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.IncludeNamespaces != nil {
+		in, out := &in.IncludeNamespaces, &out.IncludeNamespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExcludeNamespaces != nil {
+		in, out := &in.ExcludeNamespaces, &out.ExcludeNamespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ReadPreferenceTags != nil {
+		in, out := &in.ReadPreferenceTags, &out.ReadPreferenceTags
+		*out = make([]map[string]string, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = make(map[string]string, len(*in))
+				for key, val := range *in {
+					(*out)[key] = val
+				}
+			}
+		}
+	}
+	if in.Oplog != nil {
+		in, out := &in.Oplog, &out.Oplog
+		*out = new(MongoDBOplog)
+		**out = **in
+	}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(MongoDBTLS)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MongoDBBackupPlanSpec.
+func (in *MongoDBBackupPlanSpec) DeepCopy() *MongoDBBackupPlanSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(MongoDBBackupPlanSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MongoDBOplog) DeepCopyInto(out *MongoDBOplog) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MongoDBOplog.
+func (in *MongoDBOplog) DeepCopy() *MongoDBOplog {
+	if in == nil {
+		return nil
+	}
+	out := new(MongoDBOplog)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MongoDBTLS) DeepCopyInto(out *MongoDBTLS) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MongoDBTLS.
+func (in *MongoDBTLS) DeepCopy() *MongoDBTLS {
+	if in == nil {
+		return nil
+	}
+	out := new(MongoDBTLS)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MySQLBackupPlan) DeepCopyInto(out *MySQLBackupPlan) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MySQLBackupPlan.
+func (in *MySQLBackupPlan) DeepCopy() *MySQLBackupPlan {
+	if in == nil {
+		return nil
+	}
+	out := new(MySQLBackupPlan)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *MySQLBackupPlan) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MySQLBackupPlanList) DeepCopyInto(out *MySQLBackupPlanList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]MySQLBackupPlan, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MySQLBackupPlanList.
+func (in *MySQLBackupPlanList) DeepCopy() *MySQLBackupPlanList {
+	if in == nil {
+		return nil
+	}
+	out := new(MySQLBackupPlanList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *MySQLBackupPlanList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MySQLBackupPlanSpec) DeepCopyInto(out *MySQLBackupPlanSpec) {
+	*out = *in
+	in.BackupPlanSpec.DeepCopyInto(&out.BackupPlanSpec)
+	if in.Databases != nil {
+		in, out := &in.Databases, &out.Databases
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MySQLBackupPlanSpec.
+func (in *MySQLBackupPlanSpec) DeepCopy() *MySQLBackupPlanSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(MySQLBackupPlanSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PostgreSQLBackupPlan) DeepCopyInto(out *PostgreSQLBackupPlan) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PostgreSQLBackupPlan.
+func (in *PostgreSQLBackupPlan) DeepCopy() *PostgreSQLBackupPlan {
+	if in == nil {
+		return nil
+	}
+	out := new(PostgreSQLBackupPlan)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PostgreSQLBackupPlan) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PostgreSQLBackupPlanList) DeepCopyInto(out *PostgreSQLBackupPlanList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]PostgreSQLBackupPlan, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PostgreSQLBackupPlanList.
+func (in *PostgreSQLBackupPlanList) DeepCopy() *PostgreSQLBackupPlanList {
+	if in == nil {
+		return nil
+	}
+	out := new(PostgreSQLBackupPlanList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PostgreSQLBackupPlanList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PostgreSQLBackupPlanSpec) DeepCopyInto(out *PostgreSQLBackupPlanSpec) {
+	*out = *in
+	in.BackupPlanSpec.DeepCopyInto(&out.BackupPlanSpec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PostgreSQLBackupPlanSpec.
+func (in *PostgreSQLBackupPlanSpec) DeepCopy() *PostgreSQLBackupPlanSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PostgreSQLBackupPlanSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Pushgateway) DeepCopyInto(out *Pushgateway) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Pushgateway.
+func (in *Pushgateway) DeepCopy() *Pushgateway {
+	if in == nil {
+		return nil
+	}
+	out := new(Pushgateway)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RedisBackupPlan) DeepCopyInto(out *RedisBackupPlan) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RedisBackupPlan.
+func (in *RedisBackupPlan) DeepCopy() *RedisBackupPlan {
+	if in == nil {
+		return nil
+	}
+	out := new(RedisBackupPlan)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RedisBackupPlan) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RedisBackupPlanList) DeepCopyInto(out *RedisBackupPlanList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]RedisBackupPlan, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RedisBackupPlanList.
+func (in *RedisBackupPlanList) DeepCopy() *RedisBackupPlanList {
+	if in == nil {
+		return nil
+	}
+	out := new(RedisBackupPlanList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RedisBackupPlanList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RedisBackupPlanSpec) DeepCopyInto(out *RedisBackupPlanSpec) {
+	*out = *in
+	in.BackupPlanSpec.DeepCopyInto(&out.BackupPlanSpec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RedisBackupPlanSpec.
+func (in *RedisBackupPlanSpec) DeepCopy() *RedisBackupPlanSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RedisBackupPlanSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *S3) DeepCopyInto(out *S3) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new S3.
+func (in *S3) DeepCopy() *S3 {
+	if in == nil {
+		return nil
+	}
+	out := new(S3)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *S3BackupPlan) DeepCopyInto(out *S3BackupPlan) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new S3BackupPlan.
+func (in *S3BackupPlan) DeepCopy() *S3BackupPlan {
+	if in == nil {
+		return nil
+	}
+	out := new(S3BackupPlan)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *S3BackupPlan) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *S3BackupPlanList) DeepCopyInto(out *S3BackupPlanList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]S3BackupPlan, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new S3BackupPlanList.
+func (in *S3BackupPlanList) DeepCopy() *S3BackupPlanList {
+	if in == nil {
+		return nil
+	}
+	out := new(S3BackupPlanList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *S3BackupPlanList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *S3BackupPlanSpec) DeepCopyInto(out *S3BackupPlanSpec) {
+	*out = *in
+	in.BackupPlanSpec.DeepCopyInto(&out.BackupPlanSpec)
+	out.Source = in.Source
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new S3BackupPlanSpec.
+func (in *S3BackupPlanSpec) DeepCopy() *S3BackupPlanSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(S3BackupPlanSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SFTP) DeepCopyInto(out *SFTP) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SFTP.
+func (in *SFTP) DeepCopy() *SFTP {
+	if in == nil {
+		return nil
+	}
+	out := new(SFTP)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VaultBackupPlan) DeepCopyInto(out *VaultBackupPlan) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultBackupPlan.
+func (in *VaultBackupPlan) DeepCopy() *VaultBackupPlan {
+	if in == nil {
+		return nil
+	}
+	out := new(VaultBackupPlan)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VaultBackupPlan) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VaultBackupPlanList) DeepCopyInto(out *VaultBackupPlanList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]VaultBackupPlan, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultBackupPlanList.
+func (in *VaultBackupPlanList) DeepCopy() *VaultBackupPlanList {
+	if in == nil {
+		return nil
+	}
+	out := new(VaultBackupPlanList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VaultBackupPlanList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VaultBackupPlanSpec) DeepCopyInto(out *VaultBackupPlanSpec) {
+	*out = *in
+	in.BackupPlanSpec.DeepCopyInto(&out.BackupPlanSpec)
+	if in.Kubernetes != nil {
+		in, out := &in.Kubernetes, &out.Kubernetes
+		*out = new(VaultKubernetesAuth)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultBackupPlanSpec.
+func (in *VaultBackupPlanSpec) DeepCopy() *VaultBackupPlanSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VaultBackupPlanSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VaultKubernetesAuth) DeepCopyInto(out *VaultKubernetesAuth) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultKubernetesAuth.
+func (in *VaultKubernetesAuth) DeepCopy() *VaultKubernetesAuth {
+	if in == nil {
+		return nil
+	}
+	out := new(VaultKubernetesAuth)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Volume) DeepCopyInto(out *Volume) {
+	*out = *in
+	if in.FSGroup != nil {
+		in, out := &in.FSGroup, &out.FSGroup
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Volume.
+func (in *Volume) DeepCopy() *Volume {
+	if in == nil {
+		return nil
+	}
+	out := new(Volume)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VolumeBackupPlan) DeepCopyInto(out *VolumeBackupPlan) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumeBackupPlan.
+func (in *VolumeBackupPlan) DeepCopy() *VolumeBackupPlan {
+	if in == nil {
+		return nil
+	}
+	out := new(VolumeBackupPlan)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VolumeBackupPlan) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VolumeBackupPlanList) DeepCopyInto(out *VolumeBackupPlanList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]VolumeBackupPlan, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumeBackupPlanList.
+func (in *VolumeBackupPlanList) DeepCopy() *VolumeBackupPlanList {
+	if in == nil {
+		return nil
+	}
+	out := new(VolumeBackupPlanList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VolumeBackupPlanList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VolumeBackupPlanSpec) DeepCopyInto(out *VolumeBackupPlanSpec) {
+	*out = *in
+	in.BackupPlanSpec.DeepCopyInto(&out.BackupPlanSpec)
+	if in.Include != nil {
+		in, out := &in.Include, &out.Include
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Exclude != nil {
+		in, out := &in.Exclude, &out.Exclude
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.RunAsUser != nil {
+		in, out := &in.RunAsUser, &out.RunAsUser
+		*out = new(int64)
+		**out = **in
+	}
+	if in.FSGroup != nil {
+		in, out := &in.FSGroup, &out.FSGroup
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumeBackupPlanSpec.
+func (in *VolumeBackupPlanSpec) DeepCopy() *VolumeBackupPlanSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VolumeBackupPlanSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WebDAV) DeepCopyInto(out *WebDAV) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WebDAV.
+func (in *WebDAV) DeepCopy() *WebDAV {
+	if in == nil {
+		return nil
+	}
+	out := new(WebDAV)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -90,6 +90,116 @@ func main() {
 		setupLog.Error(err, "unable to create controller", "controller", "ConsulBackupPlan")
 		os.Exit(1)
 	}
+	if err = (&controllers.BackupPlanReconciler{
+		Client:             mgr.GetClient(),
+		Log:                ctrl.Log.WithName("controllers").WithName("PostgreSQLBackupPlan"),
+		Scheme:             mgr.GetScheme(),
+		DefaultDestination: nil, // TODO
+		WorkerImage:        workerImage,
+		Type:               &backupv1alpha1.PostgreSQLBackupPlan{},
+	}).SetupWithManager(mgr, "postgresqlbackupplan"); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "PostgreSQLBackupPlan")
+		os.Exit(1)
+	}
+	if err = (&controllers.BackupPlanReconciler{
+		Client:             mgr.GetClient(),
+		Log:                ctrl.Log.WithName("controllers").WithName("MySQLBackupPlan"),
+		Scheme:             mgr.GetScheme(),
+		DefaultDestination: nil, // TODO
+		WorkerImage:        workerImage,
+		Type:               &backupv1alpha1.MySQLBackupPlan{},
+	}).SetupWithManager(mgr, "mysqlbackupplan"); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "MySQLBackupPlan")
+		os.Exit(1)
+	}
+	if err = (&controllers.BackupPlanReconciler{
+		Client:             mgr.GetClient(),
+		Log:                ctrl.Log.WithName("controllers").WithName("RedisBackupPlan"),
+		Scheme:             mgr.GetScheme(),
+		DefaultDestination: nil, // TODO
+		WorkerImage:        workerImage,
+		Type:               &backupv1alpha1.RedisBackupPlan{},
+	}).SetupWithManager(mgr, "redisbackupplan"); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "RedisBackupPlan")
+		os.Exit(1)
+	}
+	if err = (&controllers.BackupPlanReconciler{
+		Client:             mgr.GetClient(),
+		Log:                ctrl.Log.WithName("controllers").WithName("EtcdBackupPlan"),
+		Scheme:             mgr.GetScheme(),
+		DefaultDestination: nil, // TODO
+		WorkerImage:        workerImage,
+		Type:               &backupv1alpha1.EtcdBackupPlan{},
+	}).SetupWithManager(mgr, "etcdbackupplan"); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "EtcdBackupPlan")
+		os.Exit(1)
+	}
+	if err = (&controllers.BackupPlanReconciler{
+		Client:             mgr.GetClient(),
+		Log:                ctrl.Log.WithName("controllers").WithName("VaultBackupPlan"),
+		Scheme:             mgr.GetScheme(),
+		DefaultDestination: nil, // TODO
+		WorkerImage:        workerImage,
+		Type:               &backupv1alpha1.VaultBackupPlan{},
+	}).SetupWithManager(mgr, "vaultbackupplan"); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "VaultBackupPlan")
+		os.Exit(1)
+	}
+	if err = (&controllers.BackupPlanReconciler{
+		Client:             mgr.GetClient(),
+		Log:                ctrl.Log.WithName("controllers").WithName("VolumeBackupPlan"),
+		Scheme:             mgr.GetScheme(),
+		DefaultDestination: nil, // TODO
+		WorkerImage:        workerImage,
+		Type:               &backupv1alpha1.VolumeBackupPlan{},
+	}).SetupWithManager(mgr, "volumebackupplan"); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "VolumeBackupPlan")
+		os.Exit(1)
+	}
+	if err = (&controllers.BackupPlanReconciler{
+		Client:             mgr.GetClient(),
+		Log:                ctrl.Log.WithName("controllers").WithName("KubernetesBackupPlan"),
+		Scheme:             mgr.GetScheme(),
+		DefaultDestination: nil, // TODO
+		WorkerImage:        workerImage,
+		Type:               &backupv1alpha1.KubernetesBackupPlan{},
+	}).SetupWithManager(mgr, "kubernetesbackupplan"); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "KubernetesBackupPlan")
+		os.Exit(1)
+	}
+	if err = (&controllers.BackupPlanReconciler{
+		Client:             mgr.GetClient(),
+		Log:                ctrl.Log.WithName("controllers").WithName("ExecBackupPlan"),
+		Scheme:             mgr.GetScheme(),
+		DefaultDestination: nil, // TODO
+		WorkerImage:        workerImage,
+		Type:               &backupv1alpha1.ExecBackupPlan{},
+	}).SetupWithManager(mgr, "execbackupplan"); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "ExecBackupPlan")
+		os.Exit(1)
+	}
+	if err = (&controllers.BackupPlanReconciler{
+		Client:             mgr.GetClient(),
+		Log:                ctrl.Log.WithName("controllers").WithName("S3BackupPlan"),
+		Scheme:             mgr.GetScheme(),
+		DefaultDestination: nil, // TODO
+		WorkerImage:        workerImage,
+		Type:               &backupv1alpha1.S3BackupPlan{},
+	}).SetupWithManager(mgr, "s3backupplan"); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "S3BackupPlan")
+		os.Exit(1)
+	}
+	if err = (&controllers.BackupPlanReconciler{
+		Client:             mgr.GetClient(),
+		Log:                ctrl.Log.WithName("controllers").WithName("HTTPBackupPlan"),
+		Scheme:             mgr.GetScheme(),
+		DefaultDestination: nil, // TODO
+		WorkerImage:        workerImage,
+		Type:               &backupv1alpha1.HTTPBackupPlan{},
+	}).SetupWithManager(mgr, "httpbackupplan"); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "HTTPBackupPlan")
+		os.Exit(1)
+	}
 	// +kubebuilder:scaffold:builder
 
 	setupLog.Info("starting manager")
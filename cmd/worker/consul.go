@@ -24,8 +24,8 @@ import (
 	"time"
 
 	backupv1alpha1 "github.com/kubism/backup-operator/api/v1alpha1"
+	"github.com/kubism/backup-operator/pkg/backup"
 	"github.com/kubism/backup-operator/pkg/backup/consul"
-	"github.com/kubism/backup-operator/pkg/backup/s3"
 	"github.com/kubism/backup-operator/pkg/logger"
 	"github.com/kubism/backup-operator/pkg/metrics"
 	"github.com/kubism/backup-operator/pkg/util"
@@ -74,24 +74,33 @@ var consulCmd = &cobra.Command{
 			mp.PublishMetrics()
 		}()
 		// Backup
-		name := fmt.Sprintf("backup-%s.tgz", time.Now().Format("20060102150405"))
-		src, err := consul.NewConsulSource(plan.Spec.Address, util.FallbackToEnv(plan.Spec.Username, "CONSUL_HTTP_USERNAME"), util.FallbackToEnv(plan.Spec.Password, "CONSUL_HTTP_PASSWORD"), name)
+		mp.StartTimer()
+		consulConf := &consul.ConsulConf{
+			Address:    plan.Spec.Address,
+			Username:   util.FallbackToEnv(plan.Spec.Username, "CONSUL_HTTP_USERNAME"),
+			Password:   util.FallbackToEnv(plan.Spec.Password, "CONSUL_HTTP_PASSWORD"),
+			Token:      util.FallbackToEnv(plan.Spec.Token, "CONSUL_HTTP_TOKEN"),
+			Datacenter: plan.Spec.Datacenter,
+			Stale:      plan.Spec.Stale,
+		}
+		if tls := plan.Spec.TLS; tls != nil {
+			consulConf.CAFile = util.FallbackToEnv(tls.CAFile, "CONSUL_CACERT")
+			consulConf.CertFile = util.FallbackToEnv(tls.CertFile, "CONSUL_CLIENT_CERT")
+			consulConf.KeyFile = util.FallbackToEnv(tls.KeyFile, "CONSUL_CLIENT_KEY")
+			consulConf.InsecureSkipVerify = tls.InsecureSkipVerify
+		}
+		var src backup.Source
+		if plan.Spec.Mode == backupv1alpha1.ConsulBackupModeKV {
+			name := fmt.Sprintf("backup-%s.json", time.Now().Format("20060102150405"))
+			src, err = consul.NewConsulKVSource(consulConf, plan.Spec.Prefixes, name)
+		} else {
+			name := fmt.Sprintf("backup-%s.tgz", time.Now().Format("20060102150405"))
+			src, err = consul.NewConsulSource(consulConf, name)
+		}
 		if err != nil {
 			return err
 		}
-		prefix := fmt.Sprintf("%s/%s", plan.ObjectMeta.Namespace, plan.ObjectMeta.Name)
-		s3c := plan.Spec.Destination.S3
-		conf := &s3.S3DestinationConf{
-			Endpoint:            s3c.Endpoint,
-			AccessKey:           util.FallbackToEnv(s3c.AccessKeyID, "S3_ACCESS_KEY_ID"),
-			SecretKey:           util.FallbackToEnv(s3c.SecretAccessKey, "S3_SECRET_ACCESS_KEY"),
-			EncryptionKey:       util.NilIfEmpty(util.FallbackToEnv(s3c.EncryptionKey, "S3_ENCRYPTION_KEY")),
-			EncryptionAlgorithm: util.FallbackToEnv(s3c.EncryptionAlgorithm, "S3_ENCRYPTION_ALGORITHM"),
-			DisableSSL:          !s3c.UseSSL,
-			Bucket:              s3c.Bucket,
-			Prefix:              prefix,
-		}
-		dst, err := s3.NewS3Destination(conf)
+		dst, err := newDestination(&plan)
 		if err != nil {
 			return err
 		}
@@ -99,6 +108,10 @@ var consulCmd = &cobra.Command{
 		if err != nil {
 			return err
 		}
+		if p, ok := src.(consul.SnapshotMetaProvider); ok && p.SnapshotMeta() != nil {
+			mp.SetSourceValue("raft_index", float64(p.SnapshotMeta().Index))
+			mp.SetSourceValue("raft_term", float64(p.SnapshotMeta().Term))
+		}
 		mp.SetBackupSizeInBytes(written)
 		err = dst.EnsureRetention(int(plan.Spec.Retention))
 		if err != nil {
@@ -0,0 +1,143 @@
+/*
+Copyright 2020 Backup Operator Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/aws/aws-sdk-go/service/s3/s3manager"
+	backupv1alpha1 "github.com/kubism/backup-operator/api/v1alpha1"
+	"github.com/kubism/backup-operator/pkg/backup"
+	"github.com/kubism/backup-operator/pkg/backup/azure"
+	"github.com/kubism/backup-operator/pkg/backup/fs"
+	"github.com/kubism/backup-operator/pkg/backup/gcs"
+	"github.com/kubism/backup-operator/pkg/backup/s3"
+	"github.com/kubism/backup-operator/pkg/backup/sftp"
+	"github.com/kubism/backup-operator/pkg/backup/webdav"
+	"github.com/kubism/backup-operator/pkg/util"
+)
+
+// objectDestination is implemented by all destinations backups of plans can
+// be stored in
+type objectDestination interface {
+	backup.Destination
+	EnsureRetention(max int) error
+	ListObjectNames() ([]string, error)
+	DeleteObjects(names []string) error
+}
+
+// newDestination returns the configured destination storing objects below
+// the namespace and name of the plan
+func newDestination(plan backupv1alpha1.BackupPlan) (objectDestination, error) {
+	prefix := fmt.Sprintf("%s/%s", plan.GetNamespace(), plan.GetName())
+	d := plan.GetSpec().Destination
+	if err := validateDestination(d); err != nil {
+		return nil, err
+	}
+	switch {
+	case d.Azure != nil:
+		return azure.NewAzureDestination(&azure.AzureDestinationConf{
+			Endpoint:   d.Azure.Endpoint,
+			Account:    d.Azure.Account,
+			Container:  d.Azure.Container,
+			SASToken:   util.FallbackToEnv(d.Azure.SASToken, "AZURE_STORAGE_SAS_TOKEN"),
+			SharedKey:  util.FallbackToEnv(d.Azure.SharedKey, "AZURE_STORAGE_KEY"),
+			Prefix:     prefix,
+			BlockSize:  int(d.Azure.BlockSize),
+			AccessTier: d.Azure.AccessTier,
+		})
+	case d.GCS != nil:
+		return gcs.NewGCSDestination(&gcs.GCSDestinationConf{
+			Endpoint:      d.GCS.Endpoint,
+			Credentials:   util.FallbackToEnv(d.GCS.Credentials, "GCS_CREDENTIALS"),
+			EncryptionKey: util.NilIfEmpty(util.FallbackToEnv(d.GCS.EncryptionKey, "GCS_ENCRYPTION_KEY")),
+			Bucket:        d.GCS.Bucket,
+			Project:       d.GCS.Project,
+			Prefix:        prefix,
+			ChunkSize:     int(d.GCS.ChunkSize),
+		})
+	case d.WebDAV != nil:
+		return webdav.NewWebDAVDestination(&webdav.WebDAVDestinationConf{
+			ClientConf: webdav.ClientConf{
+				URL:         d.WebDAV.URL,
+				UploadsURL:  d.WebDAV.UploadsURL,
+				Username:    d.WebDAV.Username,
+				Password:    util.FallbackToEnv(d.WebDAV.Password, "WEBDAV_PASSWORD"),
+				BearerToken: util.FallbackToEnv(d.WebDAV.BearerToken, "WEBDAV_BEARER_TOKEN"),
+			},
+			Prefix:    prefix,
+			ChunkSize: int(d.WebDAV.ChunkSize),
+		})
+	case d.Volume != nil:
+		// mounted by the controller
+		return fs.NewDirDestination(filepath.Join(backupv1alpha1.DestinationVolumeMountPath, prefix))
+	case d.SFTP != nil:
+		return sftp.NewSFTPDestination(&sftp.SFTPDestinationConf{
+			ClientConf: sftp.ClientConf{
+				Host:                 d.SFTP.Host,
+				Port:                 int(d.SFTP.Port),
+				Username:             d.SFTP.Username,
+				Password:             util.FallbackToEnv(d.SFTP.Password, "SFTP_PASSWORD"),
+				PrivateKeyFile:       d.SFTP.PrivateKeyFile,
+				PrivateKeyPassphrase: util.FallbackToEnv(d.SFTP.PrivateKeyPassphrase, "SFTP_PRIVATE_KEY_PASSPHRASE"),
+				HostKey:              d.SFTP.HostKey,
+			},
+			Directory: d.SFTP.Directory,
+			Prefix:    prefix,
+		})
+	case d.S3 != nil:
+		return s3.NewS3Destination(&s3.S3DestinationConf{
+			Endpoint:            d.S3.Endpoint,
+			AccessKey:           util.FallbackToEnv(d.S3.AccessKeyID, "S3_ACCESS_KEY_ID"),
+			SecretKey:           util.FallbackToEnv(d.S3.SecretAccessKey, "S3_SECRET_ACCESS_KEY"),
+			EncryptionKey:       util.NilIfEmpty(util.FallbackToEnv(d.S3.EncryptionKey, "S3_ENCRYPTION_KEY")),
+			EncryptionAlgorithm: util.FallbackToEnv(d.S3.EncryptionAlgorithm, "S3_ENCRYPTION_ALGORITHM"),
+			DisableSSL:          !d.S3.UseSSL,
+			Bucket:              d.S3.Bucket,
+			Prefix:              prefix,
+			PartSize:            util.DefaultIfZeroValueInt64(d.S3.PartSize, s3manager.MinUploadPartSize),
+		})
+	default:
+		return nil, fmt.Errorf("no destination configured")
+	}
+}
+
+// validateDestination makes sure exactly one destination is configured, as
+// it would be ambiguous which one to use otherwise
+func validateDestination(d *backupv1alpha1.Destination) error {
+	if d == nil {
+		return fmt.Errorf("no destination configured")
+	}
+	configured := 0
+	for _, isSet := range []bool{
+		d.S3 != nil,
+		d.GCS != nil,
+		d.Azure != nil,
+		d.SFTP != nil,
+		d.Volume != nil,
+		d.WebDAV != nil,
+	} {
+		if isSet {
+			configured++
+		}
+	}
+	if configured != 1 {
+		return fmt.Errorf("exactly one destination has to be configured, got %d", configured)
+	}
+	return nil
+}
@@ -0,0 +1,112 @@
+/*
+Copyright 2020 Backup Operator Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"time"
+
+	backupv1alpha1 "github.com/kubism/backup-operator/api/v1alpha1"
+	"github.com/kubism/backup-operator/pkg/backup/etcd"
+	"github.com/kubism/backup-operator/pkg/logger"
+	"github.com/kubism/backup-operator/pkg/metrics"
+	"github.com/kubism/backup-operator/pkg/util"
+	"github.com/spf13/cobra"
+)
+
+var etcdCmd = &cobra.Command{
+	Use:   "etcd [flags] config",
+	Short: "Backups etcd using specified config",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		log := logger.WithName("worker")
+		// Load configuration
+		if len(args) != 1 {
+			return fmt.Errorf("config path expected as one and only argument")
+		}
+		file, err := os.Open(args[0])
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		raw, err := ioutil.ReadAll(file)
+		if err != nil {
+			return err
+		}
+		var plan backupv1alpha1.EtcdBackupPlan
+		err = json.Unmarshal([]byte(os.ExpandEnv(string(raw))), &plan)
+		if err != nil {
+			return err
+		}
+		// Setup metrics publisher
+		mps := plan.Spec.Pushgateway
+		mpc := metrics.DefaultConfig().
+			WithApp("etcd").
+			WithURL(util.FallbackToEnv(mps.URL, "PUSHGATEWAY_URL")).
+			WithUsername(util.FallbackToEnv(mps.Username, "PUSHGATEWAY_USERNAME")).
+			WithPassword(util.FallbackToEnv(mps.Password, "PUSHGATEWAY_PASSWORD"))
+		var mp metrics.MetricsPublisher
+		if err := mpc.Validate(); err != nil {
+			log.Error(err, "invalid metrics configuration falling back to NewNopMetricsPublisher")
+			mp = metrics.NewNopMetricsPublisher()
+		} else {
+			mp = metrics.NewMetricsPublisher(mpc)
+		}
+		defer func() {
+			mp.StopTimer()
+			mp.PublishMetrics()
+		}()
+		// Backup
+		mp.StartTimer()
+		name := fmt.Sprintf("backup-%s.db", time.Now().Format("20060102150405"))
+		etcdConf := &etcd.EtcdConf{
+			Endpoints: plan.Spec.Endpoints,
+			Username:  util.FallbackToEnv(plan.Spec.Username, "ETCD_USERNAME"),
+			Password:  util.FallbackToEnv(plan.Spec.Password, "ETCD_PASSWORD"),
+		}
+		if tls := plan.Spec.TLS; tls != nil {
+			etcdConf.CAFile = tls.CAFile
+			etcdConf.CertFile = tls.CertFile
+			etcdConf.KeyFile = tls.KeyFile
+		}
+		src, err := etcd.NewEtcdSource(etcdConf, name)
+		if err != nil {
+			return err
+		}
+		dst, err := newDestination(&plan)
+		if err != nil {
+			return err
+		}
+		written, err := src.Stream(dst)
+		if err != nil {
+			return err
+		}
+		mp.SetBackupSizeInBytes(written)
+		err = dst.EnsureRetention(int(plan.Spec.Retention))
+		if err != nil {
+			return err
+		}
+		mp.SetSuccessfulRun()
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(etcdCmd)
+}
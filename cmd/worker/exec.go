@@ -0,0 +1,121 @@
+/*
+Copyright 2020 Backup Operator Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"time"
+
+	backupv1alpha1 "github.com/kubism/backup-operator/api/v1alpha1"
+	"github.com/kubism/backup-operator/pkg/backup/exec"
+	"github.com/kubism/backup-operator/pkg/logger"
+	"github.com/kubism/backup-operator/pkg/metrics"
+	"github.com/kubism/backup-operator/pkg/util"
+	"github.com/spf13/cobra"
+)
+
+var execCmd = &cobra.Command{
+	Use:   "exec [flags] config",
+	Short: "Backups output of a command using specified config",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		log := logger.WithName("worker")
+		// Load configuration
+		if len(args) != 1 {
+			return fmt.Errorf("config path expected as one and only argument")
+		}
+		file, err := os.Open(args[0])
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		raw, err := ioutil.ReadAll(file)
+		if err != nil {
+			return err
+		}
+		var plan backupv1alpha1.ExecBackupPlan
+		err = json.Unmarshal([]byte(os.ExpandEnv(string(raw))), &plan)
+		if err != nil {
+			return err
+		}
+		// Setup metrics publisher
+		mps := plan.Spec.Pushgateway
+		mpc := metrics.DefaultConfig().
+			WithApp("exec").
+			WithURL(util.FallbackToEnv(mps.URL, "PUSHGATEWAY_URL")).
+			WithUsername(util.FallbackToEnv(mps.Username, "PUSHGATEWAY_USERNAME")).
+			WithPassword(util.FallbackToEnv(mps.Password, "PUSHGATEWAY_PASSWORD"))
+		var mp metrics.MetricsPublisher
+		if err := mpc.Validate(); err != nil {
+			log.Error(err, "invalid metrics configuration falling back to NewNopMetricsPublisher")
+			mp = metrics.NewNopMetricsPublisher()
+		} else {
+			log.Info("using pushgateway for metrics", "url", mpc.URL)
+			mp = metrics.NewMetricsPublisher(mpc)
+		}
+		mp.StartTimer()
+		defer func() {
+			mp.StopTimer()
+			mp.PublishMetrics()
+		}()
+		// Backup
+		extension := plan.Spec.Extension
+		if extension == "" {
+			extension = "out"
+		}
+		name := fmt.Sprintf("backup-%s.%s", time.Now().Format("20060102150405"), extension)
+		src, err := exec.NewExecSource(plan.Spec.Command, name)
+		if err != nil {
+			return err
+		}
+		dst, err := newDestination(&plan)
+		if err != nil {
+			return err
+		}
+		written, err := src.Stream(dst)
+		publishExitCode(mp, err)
+		if err != nil {
+			return err
+		}
+		mp.SetBackupSizeInBytes(written)
+		err = dst.EnsureRetention(int(plan.Spec.Retention))
+		if err != nil {
+			return err
+		}
+		mp.SetSuccessfulRun()
+		return nil
+	},
+}
+
+// publishExitCode publishes the exit code of the command, if it ran
+func publishExitCode(mp metrics.MetricsPublisher, err error) {
+	exitCode := 0
+	if err != nil {
+		cmdErr, ok := err.(*exec.CommandError)
+		if !ok {
+			return
+		}
+		exitCode = cmdErr.ExitCode
+	}
+	mp.SetSourceValue("exit_code", float64(exitCode))
+}
+
+func init() {
+	rootCmd.AddCommand(execCmd)
+}
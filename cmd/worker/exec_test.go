@@ -0,0 +1,70 @@
+/*
+Copyright 2020 Backup Operator Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"github.com/kubism/backup-operator/pkg/backup/exec"
+	"github.com/kubism/backup-operator/pkg/backup/mem"
+	"github.com/kubism/backup-operator/pkg/metrics"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+type recordingMetricsPublisher struct {
+	metrics.MetricsPublisher
+	values map[string]float64
+}
+
+func newRecordingMetricsPublisher() *recordingMetricsPublisher {
+	return &recordingMetricsPublisher{
+		MetricsPublisher: metrics.NewNopMetricsPublisher(),
+		values:           map[string]float64{},
+	}
+}
+
+func (r *recordingMetricsPublisher) SetSourceValue(name string, value float64) {
+	r.values[name] = value
+}
+
+func streamExec(command []string) (*recordingMetricsPublisher, error) {
+	src, err := exec.NewExecSource(command, "test.out")
+	Expect(err).ToNot(HaveOccurred())
+	dst, _ := mem.NewBufferDestination()
+	mp := newRecordingMetricsPublisher()
+	_, err = src.Stream(dst)
+	publishExitCode(mp, err)
+	return mp, err
+}
+
+var _ = Describe("publishExitCode", func() {
+	It("should publish exit code of successful command", func() {
+		mp, err := streamExec([]string{"sh", "-c", "echo hello"})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(mp.values).To(HaveKeyWithValue("exit_code", float64(0)))
+	})
+	It("should publish exit code of failed command", func() {
+		mp, err := streamExec([]string{"sh", "-c", "exit 3"})
+		Expect(err).To(HaveOccurred())
+		Expect(mp.values).To(HaveKeyWithValue("exit_code", float64(3)))
+	})
+	It("should publish -1 if command could not be started", func() {
+		mp, err := streamExec([]string{"/does/not/exist"})
+		Expect(err).To(HaveOccurred())
+		Expect(mp.values).To(HaveKeyWithValue("exit_code", float64(-1)))
+	})
+})
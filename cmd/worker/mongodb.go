@@ -19,14 +19,12 @@ package main
 import (
 	"encoding/json"
 	"fmt"
-	"github.com/aws/aws-sdk-go/service/s3/s3manager"
 	"io/ioutil"
 	"os"
 	"time"
 
 	backupv1alpha1 "github.com/kubism/backup-operator/api/v1alpha1"
 	"github.com/kubism/backup-operator/pkg/backup/mongodb"
-	"github.com/kubism/backup-operator/pkg/backup/s3"
 	"github.com/kubism/backup-operator/pkg/logger"
 	"github.com/kubism/backup-operator/pkg/metrics"
 	"github.com/kubism/backup-operator/pkg/util"
@@ -78,33 +76,53 @@ var mongodbCmd = &cobra.Command{
 		// Backup
 		mp.StartTimer()
 		name := fmt.Sprintf("backup-%s.tgz", time.Now().Format("20060102150405"))
-		src, err := mongodb.NewMongoDBSource(plan.Spec.URI, "", name)
+		if plan.Spec.Oplog != nil {
+			// oplog slices are continued from the timestamp of the backup
+			ts, err := mongodb.LatestOplogTimestamp(plan.Spec.URI, mongoDBSecurityConf(&plan))
+			if err != nil {
+				return err
+			}
+			name = mongodb.BaseArchiveName(time.Now(), ts)
+		}
+		src, err := mongodb.NewMongoDBSource(&mongodb.MongoDBSourceConf{
+			URI: plan.Spec.URI,
+			NamespaceFilter: mongodb.NamespaceFilter{
+				Database:           plan.Spec.Database,
+				IncludeCollections: plan.Spec.IncludeCollections,
+				ExcludeCollections: plan.Spec.ExcludeCollections,
+				IncludeNamespaces:  plan.Spec.IncludeNamespaces,
+				ExcludeNamespaces:  plan.Spec.ExcludeNamespaces,
+			},
+			Oplog:                  plan.Spec.Oplog != nil,
+			ReadPreference:         plan.Spec.ReadPreference,
+			ReadPreferenceTags:     plan.Spec.ReadPreferenceTags,
+			RequireSecondary:       plan.Spec.RequireSecondary,
+			NumParallelCollections: int(plan.Spec.NumParallelCollections),
+			SplitBy:                plan.Spec.SplitBy,
+			SecurityConf:           mongoDBSecurityConf(&plan),
+		}, name)
 		if err != nil {
 			return err
 		}
-		prefix := fmt.Sprintf("%s/%s", plan.ObjectMeta.Namespace, plan.ObjectMeta.Name)
-		s3c := plan.Spec.Destination.S3
-		conf := &s3.S3DestinationConf{
-			Endpoint:            s3c.Endpoint,
-			AccessKey:           util.FallbackToEnv(s3c.AccessKeyID, "S3_ACCESS_KEY_ID"),
-			SecretKey:           util.FallbackToEnv(s3c.SecretAccessKey, "S3_SECRET_ACCESS_KEY"),
-			EncryptionKey:       util.NilIfEmpty(util.FallbackToEnv(s3c.EncryptionKey, "S3_ENCRYPTION_KEY")),
-			EncryptionAlgorithm: util.FallbackToEnv(s3c.EncryptionAlgorithm, "S3_ENCRYPTION_ALGORITHM"),
-			DisableSSL:          !s3c.UseSSL,
-			Bucket:              s3c.Bucket,
-			Prefix:              prefix,
-			PartSize:            util.DefaultIfZeroValueInt64(s3c.PartSize, s3manager.MinUploadPartSize),
-		}
-		dst, err := s3.NewS3Destination(conf)
+		dst, err := newDestination(&plan)
 		if err != nil {
 			return err
 		}
 		written, err := src.Stream(dst)
+		if p, ok := src.(mongodb.MemberProvider); ok && p.Member() != "" {
+			mp.SetSourceInfo(map[string]string{"member": p.Member()})
+		}
 		if err != nil {
 			return err
 		}
 		mp.SetBackupSizeInBytes(written)
-		err = dst.EnsureRetention(int(plan.Spec.Retention))
+		if plan.Spec.Oplog != nil {
+			err = ensureOplogRetention(dst, int(plan.Spec.Retention))
+		} else if plan.Spec.SplitBy != "" {
+			err = ensureSnapshotRetention(dst, int(plan.Spec.Retention))
+		} else {
+			err = dst.EnsureRetention(int(plan.Spec.Retention))
+		}
 		if err != nil {
 			return err
 		}
@@ -113,6 +131,53 @@ var mongodbCmd = &cobra.Command{
 	},
 }
 
+// mongoDBSecurityConf maps the TLS and authentication settings of the plan
+func mongoDBSecurityConf(plan *backupv1alpha1.MongoDBBackupPlan) mongodb.SecurityConf {
+	conf := mongodb.SecurityConf{
+		AuthenticationMechanism: plan.Spec.AuthenticationMechanism,
+	}
+	if t := plan.Spec.TLS; t != nil {
+		conf.TLS = &mongodb.TLSConf{
+			CAFile:             t.CAFile,
+			CertFile:           t.CertFile,
+			KeyFile:            t.KeyFile,
+			KeyPassword:        util.FallbackToEnv(t.KeyPassword, "MONGODB_TLS_KEY_PASSWORD"),
+			InsecureSkipVerify: t.InsecureSkipVerify,
+		}
+	}
+	return conf
+}
+
+// mongoDBDestinationConf maps the settings of the plan used to restore its
+// backups
+func mongoDBDestinationConf(plan *backupv1alpha1.MongoDBBackupPlan) *mongodb.MongoDBDestinationConf {
+	return &mongodb.MongoDBDestinationConf{
+		URI:                              plan.Spec.URI,
+		NumParallelCollections:           int(plan.Spec.NumParallelCollections),
+		NumInsertionWorkersPerCollection: int(plan.Spec.NumInsertionWorkersPerCollection),
+		SecurityConf:                     mongoDBSecurityConf(plan),
+	}
+}
+
+// ensureOplogRetention keeps the backups and all oplog slices required for a
+// point-in-time recovery based on the oldest retained backup
+func ensureOplogRetention(dst objectDestination, max int) error {
+	names, err := dst.ListObjectNames()
+	if err != nil {
+		return err
+	}
+	return dst.DeleteObjects(mongodb.ObsoleteObjects(names, max))
+}
+
+// ensureSnapshotRetention keeps all archives of the retained backups
+func ensureSnapshotRetention(dst objectDestination, max int) error {
+	names, err := dst.ListObjectNames()
+	if err != nil {
+		return err
+	}
+	return dst.DeleteObjects(mongodb.ObsoleteSnapshots(names, max))
+}
+
 func init() {
 	rootCmd.AddCommand(mongodbCmd)
 }
@@ -0,0 +1,114 @@
+/*
+Copyright 2020 Backup Operator Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	backupv1alpha1 "github.com/kubism/backup-operator/api/v1alpha1"
+	"github.com/kubism/backup-operator/pkg/backup/mongodb"
+	"github.com/kubism/backup-operator/pkg/logger"
+	"github.com/kubism/backup-operator/pkg/metrics"
+	"github.com/kubism/backup-operator/pkg/util"
+	"github.com/spf13/cobra"
+)
+
+var mongodbOplogCmd = &cobra.Command{
+	Use:   "mongodb-oplog [flags] config",
+	Short: "Ships the oplog of mongodb using specified config",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		log := logger.WithName("worker")
+		// Load configuration
+		if len(args) != 1 {
+			return fmt.Errorf("config path expected as one and only argument")
+		}
+		file, err := os.Open(args[0])
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		raw, err := ioutil.ReadAll(file)
+		if err != nil {
+			return err
+		}
+		var plan backupv1alpha1.MongoDBBackupPlan
+		err = json.Unmarshal([]byte(os.ExpandEnv(string(raw))), &plan)
+		if err != nil {
+			return err
+		}
+		if plan.Spec.Oplog == nil {
+			return fmt.Errorf("oplog is not enabled")
+		}
+		// Setup metrics publisher
+		mps := plan.Spec.Pushgateway
+		mpc := metrics.DefaultConfig().
+			WithApp("mongodb-oplog").
+			WithURL(util.FallbackToEnv(mps.URL, "PUSHGATEWAY_URL")).
+			WithUsername(util.FallbackToEnv(mps.Username, "PUSHGATEWAY_USERNAME")).
+			WithPassword(util.FallbackToEnv(mps.Password, "PUSHGATEWAY_PASSWORD"))
+		var mp metrics.MetricsPublisher
+		if err := mpc.Validate(); err != nil {
+			log.Error(err, "invalid metrics configuration falling back to NewNopMetricsPublisher")
+			mp = metrics.NewNopMetricsPublisher()
+		} else {
+			log.Info("using pushgateway for metrics", "url", mpc.URL)
+			mp = metrics.NewMetricsPublisher(mpc)
+		}
+		defer func() {
+			mp.StopTimer()
+			mp.PublishMetrics()
+		}()
+		dst, err := newDestination(&plan)
+		if err != nil {
+			return err
+		}
+		// Backup
+		mp.StartTimer()
+		names, err := dst.ListObjectNames()
+		if err != nil {
+			return err
+		}
+		start, ok := mongodb.NextOplogSliceStart(names)
+		if !ok {
+			log.Info("no backup including the oplog exists yet, skipping")
+			mp.SetSuccessfulRun()
+			return nil
+		}
+		src, err := mongodb.NewMongoDBOplogSource(&mongodb.MongoDBOplogSourceConf{
+			URI:          plan.Spec.URI,
+			Start:        start,
+			SecurityConf: mongoDBSecurityConf(&plan),
+		})
+		if err != nil {
+			return err
+		}
+		written, err := src.Stream(dst)
+		if err != nil {
+			return err
+		}
+		mp.SetBackupSizeInBytes(written)
+		mp.SetSuccessfulRun()
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(mongodbOplogCmd)
+}
@@ -0,0 +1,40 @@
+/*
+Copyright 2020 Backup Operator Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	backupv1alpha1 "github.com/kubism/backup-operator/api/v1alpha1"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("mongoDBDestinationConf", func() {
+	It("should restore with the parallelism of the plan", func() {
+		plan := &backupv1alpha1.MongoDBBackupPlan{
+			Spec: backupv1alpha1.MongoDBBackupPlanSpec{
+				URI:                              "mongodb://localhost:27017",
+				NumParallelCollections:           8,
+				NumInsertionWorkersPerCollection: 2,
+			},
+		}
+		conf := mongoDBDestinationConf(plan)
+		Expect(conf.URI).To(Equal("mongodb://localhost:27017"))
+		Expect(conf.NumParallelCollections).To(Equal(8))
+		Expect(conf.NumInsertionWorkersPerCollection).To(Equal(2))
+	})
+})
@@ -0,0 +1,126 @@
+/*
+Copyright 2020 Backup Operator Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"time"
+
+	backupv1alpha1 "github.com/kubism/backup-operator/api/v1alpha1"
+	"github.com/kubism/backup-operator/pkg/backup/s3"
+	"github.com/kubism/backup-operator/pkg/logger"
+	"github.com/kubism/backup-operator/pkg/metrics"
+	"github.com/kubism/backup-operator/pkg/util"
+	"github.com/spf13/cobra"
+)
+
+var s3Cmd = &cobra.Command{
+	Use:   "s3 [flags] config",
+	Short: "Backups s3 prefix using specified config",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		log := logger.WithName("worker")
+		// Load configuration
+		if len(args) != 1 {
+			return fmt.Errorf("config path expected as one and only argument")
+		}
+		file, err := os.Open(args[0])
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		raw, err := ioutil.ReadAll(file)
+		if err != nil {
+			return err
+		}
+		var plan backupv1alpha1.S3BackupPlan
+		err = json.Unmarshal([]byte(os.ExpandEnv(string(raw))), &plan)
+		if err != nil {
+			return err
+		}
+		// Setup metrics publisher
+		mps := plan.Spec.Pushgateway
+		mpc := metrics.DefaultConfig().
+			WithApp("s3").
+			WithURL(util.FallbackToEnv(mps.URL, "PUSHGATEWAY_URL")).
+			WithUsername(util.FallbackToEnv(mps.Username, "PUSHGATEWAY_USERNAME")).
+			WithPassword(util.FallbackToEnv(mps.Password, "PUSHGATEWAY_PASSWORD"))
+		var mp metrics.MetricsPublisher
+		if err := mpc.Validate(); err != nil {
+			log.Error(err, "invalid metrics configuration falling back to NewNopMetricsPublisher")
+			mp = metrics.NewNopMetricsPublisher()
+		} else {
+			log.Info("using pushgateway for metrics", "url", mpc.URL)
+			mp = metrics.NewMetricsPublisher(mpc)
+		}
+		defer func() {
+			mp.StopTimer()
+			mp.PublishMetrics()
+		}()
+		// Backup
+		mp.StartTimer()
+		// snapshots rely on manifests, which are only supported by S3
+		if plan.Spec.Destination.S3 == nil {
+			return fmt.Errorf("only s3 destinations are supported by %s", backupv1alpha1.S3BackupPlanKind)
+		}
+		d, err := newDestination(&plan)
+		if err != nil {
+			return err
+		}
+		dst := d.(*s3.S3Destination)
+		var previous *s3.S3Manifest
+		if plan.Spec.Incremental {
+			previous, err = dst.LoadLatestManifest()
+			if err != nil {
+				return err
+			}
+		}
+		srcc := plan.Spec.Source
+		src, err := s3.NewS3PrefixSource(&s3.S3PrefixSourceConf{
+			Endpoint:            srcc.Endpoint,
+			AccessKey:           util.FallbackToEnv(srcc.AccessKeyID, "SOURCE_S3_ACCESS_KEY_ID"),
+			SecretKey:           util.FallbackToEnv(srcc.SecretAccessKey, "SOURCE_S3_SECRET_ACCESS_KEY"),
+			EncryptionKey:       util.NilIfEmpty(util.FallbackToEnv(srcc.EncryptionKey, "SOURCE_S3_ENCRYPTION_KEY")),
+			EncryptionAlgorithm: util.FallbackToEnv(srcc.EncryptionAlgorithm, "SOURCE_S3_ENCRYPTION_ALGORITHM"),
+			DisableSSL:          !srcc.UseSSL,
+			Bucket:              srcc.Bucket,
+			Prefix:              plan.Spec.Prefix,
+			SnapshotName:        fmt.Sprintf("backup-%s", time.Now().Format("20060102150405")),
+			Previous:            previous,
+		})
+		if err != nil {
+			return err
+		}
+		written, err := src.Stream(dst)
+		if err != nil {
+			return err
+		}
+		mp.SetBackupSizeInBytes(written)
+		err = dst.EnsureSnapshotRetention(int(plan.Spec.Retention))
+		if err != nil {
+			return err
+		}
+		mp.SetSuccessfulRun()
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(s3Cmd)
+}
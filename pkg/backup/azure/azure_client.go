@@ -0,0 +1,335 @@
+/*
+Copyright 2020 Backup Operator Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxErrorBodySize limits the response body included in errors
+const maxErrorBodySize = 1024
+
+type client struct {
+	Endpoint  string
+	Account   string
+	Container string
+	SASToken  url.Values
+	SharedKey []byte
+	HTTP      *http.Client
+}
+
+// blob is the subset of the blob properties used by the client
+type blob struct {
+	Name       string
+	Properties struct {
+		LastModified string `xml:"Last-Modified"`
+	}
+}
+
+func (b *blob) lastModified() time.Time {
+	t, _ := time.Parse(http.TimeFormat, b.Properties.LastModified)
+	return t
+}
+
+// newClient returns a client of the container authenticated with either a
+// SAS token or the base64 encoded shared key of the account. If the
+// endpoint is empty, the public endpoint of the account is used.
+func newClient(endpoint, account, container, sasToken, sharedKey string) (*client, error) {
+	if account == "" || container == "" {
+		return nil, fmt.Errorf("account and container are required")
+	}
+	if endpoint == "" {
+		endpoint = fmt.Sprintf("https://%s.blob.core.windows.net", account)
+	}
+	c := &client{
+		Endpoint:  strings.TrimSuffix(endpoint, "/"),
+		Account:   account,
+		Container: container,
+		HTTP:      &http.Client{},
+	}
+	switch {
+	case sasToken != "":
+		values, err := url.ParseQuery(strings.TrimPrefix(sasToken, "?"))
+		if err != nil {
+			return nil, fmt.Errorf("invalid SAS token: %v", err)
+		}
+		c.SASToken = values
+	case sharedKey != "":
+		key, err := base64.StdEncoding.DecodeString(sharedKey)
+		if err != nil {
+			return nil, fmt.Errorf("invalid shared key: %v", err)
+		}
+		c.SharedKey = key
+	default:
+		return nil, fmt.Errorf("either a SAS token or a shared key is required")
+	}
+	return c, nil
+}
+
+// newRequest returns a request of the container or of the blob, if a name
+// is provided
+func (c *client) newRequest(method, name string, query url.Values, body []byte) (*http.Request, error) {
+	u := fmt.Sprintf("%s/%s", c.Endpoint, url.PathEscape(c.Container))
+	if name != "" {
+		segments := strings.Split(name, "/")
+		for i := range segments {
+			segments[i] = url.PathEscape(segments[i])
+		}
+		u += "/" + strings.Join(segments, "/")
+	}
+	if query == nil {
+		query = url.Values{}
+	}
+	for key, values := range c.SASToken {
+		query[key] = values
+	}
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+	req, err := http.NewRequest(method, u, reader)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("x-ms-version", apiVersion)
+	req.Header.Set("x-ms-date", time.Now().UTC().Format(http.TimeFormat))
+	return req, nil
+}
+
+// do signs the request, if a shared key is used, sends it and returns an
+// error, if the response does not indicate success
+func (c *client) do(req *http.Request, expected ...int) (*http.Response, error) {
+	if c.SharedKey != nil {
+		mac := hmac.New(sha256.New, c.SharedKey)
+		mac.Write([]byte(c.stringToSign(req)))
+		signature := base64.StdEncoding.EncodeToString(mac.Sum(nil))
+		req.Header.Set("Authorization", fmt.Sprintf("SharedKey %s:%s", c.Account, signature))
+	}
+	res, err := c.HTTP.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	for _, code := range expected {
+		if res.StatusCode == code {
+			return res, nil
+		}
+	}
+	defer res.Body.Close()
+	msg, _ := ioutil.ReadAll(io.LimitReader(res.Body, maxErrorBodySize))
+	return nil, &statusError{
+		Code: res.StatusCode,
+		Msg:  fmt.Sprintf("%s %s returned %s: %s", req.Method, req.URL.Path, res.Status, strings.TrimSpace(string(msg))),
+	}
+}
+
+// stringToSign returns the string signed with the shared key, see
+// https://docs.microsoft.com/en-us/rest/api/storageservices/authorize-with-shared-key
+func (c *client) stringToSign(req *http.Request) string {
+	contentLength := ""
+	if req.ContentLength > 0 {
+		contentLength = strconv.FormatInt(req.ContentLength, 10)
+	}
+	headers := []string{}
+	for name, values := range req.Header {
+		name = strings.ToLower(name)
+		if strings.HasPrefix(name, "x-ms-") {
+			headers = append(headers, name+":"+strings.Join(values, ","))
+		}
+	}
+	sort.Strings(headers)
+	resource := "/" + c.Account + req.URL.EscapedPath()
+	query := req.URL.Query()
+	names := make([]string, 0, len(query))
+	for name := range query {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		values := query[name]
+		sort.Strings(values)
+		resource += "\n" + strings.ToLower(name) + ":" + strings.Join(values, ",")
+	}
+	return strings.Join([]string{
+		req.Method,
+		req.Header.Get("Content-Encoding"),
+		req.Header.Get("Content-Language"),
+		contentLength,
+		req.Header.Get("Content-MD5"),
+		req.Header.Get("Content-Type"),
+		"", // x-ms-date is used instead
+		req.Header.Get("If-Modified-Since"),
+		req.Header.Get("If-Match"),
+		req.Header.Get("If-None-Match"),
+		req.Header.Get("If-Unmodified-Since"),
+		req.Header.Get("Range"),
+		strings.Join(headers, "\n"),
+		resource,
+	}, "\n")
+}
+
+type statusError struct {
+	Code int
+	Msg  string
+}
+
+func (e *statusError) Error() string {
+	return e.Msg
+}
+
+// ensureContainer creates the container, if it does not exist
+func (c *client) ensureContainer() error {
+	req, err := c.newRequest(http.MethodPut, "", url.Values{"restype": {"container"}}, nil)
+	if err != nil {
+		return err
+	}
+	res, err := c.do(req, http.StatusCreated)
+	if serr, ok := err.(*statusError); ok && serr.Code == http.StatusConflict {
+		return nil // already exists
+	}
+	if err != nil {
+		return err
+	}
+	return res.Body.Close()
+}
+
+// upload stages the data in blocks of the provided size and commits them,
+// so the size of the data does not have to be known in advance
+func (c *client) upload(name string, data io.Reader, blockSize int, accessTier string) (int64, error) {
+	buf := make([]byte, blockSize)
+	ids := []string{}
+	var written int64
+	for {
+		n, err := io.ReadFull(data, buf)
+		if n > 0 {
+			// all block IDs of a blob have to be of the same length
+			id := base64.StdEncoding.EncodeToString([]byte(fmt.Sprintf("%010d", len(ids))))
+			req, err := c.newRequest(http.MethodPut, name, url.Values{"comp": {"block"}, "blockid": {id}}, buf[:n])
+			if err != nil {
+				return written, err
+			}
+			res, err := c.do(req, http.StatusCreated)
+			if err != nil {
+				return written, err
+			}
+			res.Body.Close()
+			ids = append(ids, id)
+			written += int64(n)
+		}
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			break
+		}
+		if err != nil {
+			return written, err
+		}
+	}
+	// uncommitted blocks are discarded by the service after a week
+	var list bytes.Buffer
+	list.WriteString(xml.Header + "<BlockList>")
+	for _, id := range ids {
+		list.WriteString("<Latest>" + id + "</Latest>")
+	}
+	list.WriteString("</BlockList>")
+	req, err := c.newRequest(http.MethodPut, name, url.Values{"comp": {"blocklist"}}, list.Bytes())
+	if err != nil {
+		return written, err
+	}
+	if accessTier != "" {
+		req.Header.Set("x-ms-access-tier", accessTier)
+	}
+	res, err := c.do(req, http.StatusCreated)
+	if err != nil {
+		return written, err
+	}
+	return written, res.Body.Close()
+}
+
+// download returns the data of the blob
+func (c *client) download(name string) (io.ReadCloser, error) {
+	req, err := c.newRequest(http.MethodGet, name, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	res, err := c.do(req, http.StatusOK)
+	if err != nil {
+		return nil, err
+	}
+	return res.Body, nil
+}
+
+// list returns all blobs with the provided prefix
+func (c *client) list(prefix string) ([]blob, error) {
+	blobs := []blob{}
+	marker := ""
+	for {
+		query := url.Values{"restype": {"container"}, "comp": {"list"}, "prefix": {prefix}}
+		if marker != "" {
+			query.Set("marker", marker)
+		}
+		req, err := c.newRequest(http.MethodGet, "", query, nil)
+		if err != nil {
+			return nil, err
+		}
+		res, err := c.do(req, http.StatusOK)
+		if err != nil {
+			return nil, err
+		}
+		var page struct {
+			Blobs      []blob `xml:"Blobs>Blob"`
+			NextMarker string
+		}
+		err = xml.NewDecoder(res.Body).Decode(&page)
+		res.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		blobs = append(blobs, page.Blobs...)
+		if page.NextMarker == "" {
+			return blobs, nil
+		}
+		marker = page.NextMarker
+	}
+}
+
+// delete removes the blob including its snapshots
+func (c *client) delete(name string) error {
+	req, err := c.newRequest(http.MethodDelete, name, nil, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("x-ms-delete-snapshots", "include")
+	res, err := c.do(req, http.StatusAccepted)
+	if err != nil {
+		return err
+	}
+	return res.Body.Close()
+}
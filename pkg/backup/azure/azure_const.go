@@ -0,0 +1,32 @@
+/*
+Copyright 2020 Backup Operator Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+// DefaultBlockSize of staged uploads. Azure allows up to 50000 blocks per
+// blob.
+const DefaultBlockSize = 8 * 1024 * 1024
+
+const maxBlockSize = 4000 * 1024 * 1024
+
+const apiVersion = "2020-04-08"
+
+// Access tiers of block blobs
+const (
+	AccessTierHot     = "Hot"
+	AccessTierCool    = "Cool"
+	AccessTierArchive = "Archive"
+)
@@ -0,0 +1,144 @@
+/*
+Copyright 2020 Backup Operator Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/kubism/backup-operator/pkg/backup"
+	"github.com/kubism/backup-operator/pkg/logger"
+)
+
+type AzureDestinationConf struct {
+	// Endpoint of the blob service, defaults to the public endpoint of the
+	// account
+	Endpoint  string
+	Account   string
+	Container string
+	// Either a SAS token or the base64 encoded shared key of the account is
+	// required
+	SASToken  string
+	SharedKey string
+	Prefix    string
+	// Size of the staged blocks, defaults to DefaultBlockSize
+	BlockSize int
+	// Access tier of the stored blobs, defaults to the tier of the account
+	AccessTier string
+}
+
+func NewAzureDestination(conf *AzureDestinationConf) (*AzureDestination, error) {
+	blockSize := conf.BlockSize
+	if blockSize == 0 {
+		blockSize = DefaultBlockSize
+	}
+	if blockSize < 0 || blockSize > maxBlockSize {
+		return nil, fmt.Errorf("block size has to be between 1 and %d", maxBlockSize)
+	}
+	switch conf.AccessTier {
+	case "", AccessTierHot, AccessTierCool, AccessTierArchive:
+	default:
+		return nil, fmt.Errorf("invalid access tier %q", conf.AccessTier)
+	}
+	client, err := newClient(conf.Endpoint, conf.Account, conf.Container, conf.SASToken, conf.SharedKey)
+	if err != nil {
+		return nil, err
+	}
+	if err := client.ensureContainer(); err != nil {
+		return nil, err
+	}
+	return &AzureDestination{
+		client:     client,
+		Container:  conf.Container,
+		Prefix:     conf.Prefix,
+		BlockSize:  blockSize,
+		AccessTier: conf.AccessTier,
+		log:        logger.WithName("azuredst"),
+	}, nil
+}
+
+type AzureDestination struct {
+	client     *client
+	Container  string
+	Prefix     string
+	BlockSize  int
+	AccessTier string
+	log        logger.Logger
+}
+
+func (a *AzureDestination) Store(obj backup.Object) (int64, error) {
+	name := path.Join(a.Prefix, obj.ID)
+	a.log.Info("upload starting", "container", a.Container, "name", name)
+	written, err := a.client.upload(name, obj.Data, a.BlockSize, a.AccessTier)
+	if err != nil {
+		return written, err
+	}
+	a.log.Info("upload successful", "numBytes", written)
+	return written, nil
+}
+
+// EnsureRetention deletes all but the newest max blobs of the prefix
+func (a *AzureDestination) EnsureRetention(max int) error {
+	blobs, err := a.client.list(a.Prefix)
+	if err != nil {
+		return err
+	}
+	if len(blobs) <= max {
+		return nil
+	}
+	sort.Slice(blobs, func(i, j int) bool {
+		ti, tj := blobs[i].lastModified(), blobs[j].lastModified()
+		if ti.Equal(tj) {
+			return blobs[i].Name > blobs[j].Name // names usually contain the time
+		}
+		return ti.After(tj)
+	})
+	for _, blob := range blobs[max:] {
+		if err := a.client.delete(blob.Name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ListObjectNames returns the names of all blobs of the destination
+// relative to its prefix
+func (a *AzureDestination) ListObjectNames() ([]string, error) {
+	prefix := a.Prefix + "/"
+	blobs, err := a.client.list(prefix)
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(blobs))
+	for _, blob := range blobs {
+		names = append(names, strings.TrimPrefix(blob.Name, prefix))
+	}
+	return names, nil
+}
+
+// DeleteObjects deletes the blobs with the provided names relative to the
+// prefix of the destination
+func (a *AzureDestination) DeleteObjects(names []string) error {
+	for _, name := range names {
+		if err := a.client.delete(path.Join(a.Prefix, name)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,104 @@
+/*
+Copyright 2020 Backup Operator Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"crypto/rand"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/kubism/backup-operator/pkg/backup/mem"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("AzureDestination", func() {
+	It("should upload in blocks", func() {
+		data := make([]byte, 3*1024+17)
+		_, err := rand.Read(data)
+		Expect(err).ToNot(HaveOccurred())
+		src, _ := mem.NewBufferSource("blocks", data)
+		dst := newTestDestination("containera", "prefix", "")
+		written, err := src.Stream(dst)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(written).To(Equal(int64(len(data))))
+		rc, err := dst.client.download("prefix/blocks")
+		Expect(err).ToNot(HaveOccurred())
+		defer rc.Close()
+		Expect(ioutil.ReadAll(rc)).To(Equal(data))
+	})
+	It("should upload empty blobs", func() {
+		src, _ := mem.NewBufferSource("empty", []byte{})
+		dst := newTestDestination("containera", "prefix", "")
+		written, err := src.Stream(dst)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(written).To(BeZero())
+	})
+	It("should set the access tier", func() {
+		src, _ := mem.NewBufferSource("cool", []byte("temporarycontent"))
+		dst := newTestDestination("containerb", "prefix", AccessTierCool)
+		_, err := src.Stream(dst)
+		Expect(err).ToNot(HaveOccurred())
+		req, err := dst.client.newRequest(http.MethodHead, "prefix/cool", nil, nil)
+		Expect(err).ToNot(HaveOccurred())
+		res, err := dst.client.do(req, http.StatusOK)
+		Expect(err).ToNot(HaveOccurred())
+		res.Body.Close()
+		Expect(res.Header.Get("x-ms-access-tier")).To(Equal(AccessTierCool))
+	})
+	It("should reject invalid access tiers", func() {
+		_, err := NewAzureDestination(&AzureDestinationConf{
+			Endpoint:   endpoint,
+			Account:    account,
+			Container:  "containerb",
+			SharedKey:  sharedKey,
+			AccessTier: "Lukewarm",
+		})
+		Expect(err).To(HaveOccurred())
+	})
+	It("should fail with an invalid shared key", func() {
+		_, err := NewAzureDestination(&AzureDestinationConf{
+			Endpoint:  endpoint,
+			Account:   account,
+			Container: "containerb",
+			SharedKey: "aW52YWxpZA==",
+		})
+		Expect(err).To(HaveOccurred())
+	})
+	It("should ensure retention", func() {
+		dst := newTestDestination("containerc", "ns/plan", "")
+		for _, id := range []string{"backup-1", "backup-2", "backup-3", "backup-4"} {
+			src, _ := mem.NewBufferSource(id, []byte(id))
+			_, err := src.Stream(dst)
+			Expect(err).ToNot(HaveOccurred())
+		}
+		Expect(dst.EnsureRetention(2)).To(Succeed())
+		Expect(dst.ListObjectNames()).To(ConsistOf("backup-3", "backup-4"))
+	})
+	It("should list and delete objects relative to the prefix", func() {
+		dst := newTestDestination("containerd", "ns/plan", "")
+		for _, id := range []string{"backup-1", "oplog/1-2.bson.gz"} {
+			src, _ := mem.NewBufferSource(id, []byte(id))
+			_, err := src.Stream(dst)
+			Expect(err).ToNot(HaveOccurred())
+		}
+		Expect(dst.ListObjectNames()).To(ConsistOf("backup-1", "oplog/1-2.bson.gz"))
+		Expect(dst.DeleteObjects([]string{"oplog/1-2.bson.gz"})).To(Succeed())
+		Expect(dst.ListObjectNames()).To(Equal([]string{"backup-1"}))
+	})
+})
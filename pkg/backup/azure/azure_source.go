@@ -0,0 +1,74 @@
+/*
+Copyright 2020 Backup Operator Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"github.com/kubism/backup-operator/pkg/backup"
+	"github.com/kubism/backup-operator/pkg/logger"
+)
+
+type AzureSourceConf struct {
+	// Endpoint of the blob service, defaults to the public endpoint of the
+	// account
+	Endpoint  string
+	Account   string
+	Container string
+	// Either a SAS token or the base64 encoded shared key of the account is
+	// required
+	SASToken  string
+	SharedKey string
+	Key       string
+}
+
+func NewAzureSource(conf *AzureSourceConf) (*AzureSource, error) {
+	client, err := newClient(conf.Endpoint, conf.Account, conf.Container, conf.SASToken, conf.SharedKey)
+	if err != nil {
+		return nil, err
+	}
+	return &AzureSource{
+		client:    client,
+		Container: conf.Container,
+		Key:       conf.Key,
+		log:       logger.WithName("azuresrc"),
+	}, nil
+}
+
+type AzureSource struct {
+	client    *client
+	Container string
+	Key       string
+	log       logger.Logger
+}
+
+func (a *AzureSource) Stream(dst backup.Destination) (int64, error) {
+	log := a.log
+	log.Info("download starting", "container", a.Container, "key", a.Key)
+	data, err := a.client.download(a.Key)
+	if err != nil {
+		return 0, err
+	}
+	defer data.Close()
+	written, err := dst.Store(backup.Object{
+		ID:   a.Key,
+		Data: data,
+	})
+	if err != nil {
+		return written, err
+	}
+	log.Info("finished download", "numBytes", written)
+	return written, nil
+}
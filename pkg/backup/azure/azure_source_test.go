@@ -0,0 +1,55 @@
+/*
+Copyright 2020 Backup Operator Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"github.com/kubism/backup-operator/pkg/backup/mem"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("AzureSource", func() {
+	It("should download blob", func() {
+		data := []byte("temporarycontent")
+		src, _ := mem.NewBufferSource("restore", data)
+		_, err := src.Stream(newTestDestination("containere", "prefix", ""))
+		Expect(err).ToNot(HaveOccurred())
+		azureSrc, err := NewAzureSource(&AzureSourceConf{
+			Endpoint:  endpoint,
+			Account:   account,
+			Container: "containere",
+			SharedKey: sharedKey,
+			Key:       "prefix/restore",
+		})
+		Expect(err).ToNot(HaveOccurred())
+		dst, _ := mem.NewBufferDestination()
+		written, err := azureSrc.Stream(dst)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(written).To(Equal(int64(len(data))))
+		Expect(dst.Data["prefix/restore"]).To(Equal(data))
+	})
+	It("should require credentials", func() {
+		_, err := NewAzureSource(&AzureSourceConf{
+			Endpoint:  endpoint,
+			Account:   account,
+			Container: "containere",
+			Key:       "prefix/restore",
+		})
+		Expect(err).To(HaveOccurred())
+	})
+})
@@ -0,0 +1,88 @@
+/*
+Copyright 2020 Backup Operator Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/kubism/backup-operator/pkg/logger"
+	"github.com/kubism/backup-operator/pkg/testutil"
+	"github.com/onsi/ginkgo/reporters"
+	"github.com/ory/dockertest/v3"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var (
+	pool          *dockertest.Pool
+	azureResource *dockertest.Resource
+	endpoint      string
+)
+
+// well-known development account of Azurite
+const (
+	account   = "devstoreaccount1"
+	sharedKey = "Eby8vdM02xNOcqFlqUwJPLlmEtlCDXJ1OUzFT50uSRZ6IFsuFq2UVErCz4I6tq/K1SZFPTOtr/KBHBeksoGMGw=="
+)
+
+func TestAzure(t *testing.T) {
+	RegisterFailHandler(Fail)
+	junitReporter := reporters.NewJUnitReporter("../../../reports/azure-junit.xml")
+	RunSpecsWithDefaultAndCustomReporters(t, "Azure", []Reporter{junitReporter})
+}
+
+var _ = BeforeSuite(func(done Done) {
+	var err error
+	log := logger.WithName("azuresetup")
+	By("bootstrapping Azurite")
+	pool, err = dockertest.NewPool("")
+	Expect(err).ToNot(HaveOccurred())
+	options := &dockertest.RunOptions{
+		Repository: "mcr.microsoft.com/azure-storage/azurite",
+		Tag:        "3.9.0",
+		Cmd:        []string{"azurite-blob", "--blobHost", "0.0.0.0"},
+	}
+	azureResource, err = pool.RunWithOptions(options)
+	Expect(err).ToNot(HaveOccurred())
+	endpoint = fmt.Sprintf("http://localhost:%s/%s", azureResource.GetPort("10000/tcp"), account)
+	log.Info("check Azurite connection", "endpoint", endpoint)
+	err = testutil.WaitForAzurite(pool, endpoint)
+	Expect(err).ToNot(HaveOccurred())
+	log.Info("Azurite ready")
+	close(done)
+}, 60)
+
+var _ = AfterSuite(func() {
+	By("tearing down the test environment")
+	Expect(pool.Purge(azureResource)).To(Succeed())
+})
+
+func newTestDestination(container, prefix, accessTier string) *AzureDestination {
+	dst, err := NewAzureDestination(&AzureDestinationConf{
+		Endpoint:   endpoint,
+		Account:    account,
+		Container:  container,
+		SharedKey:  sharedKey,
+		Prefix:     prefix,
+		BlockSize:  1024,
+		AccessTier: accessTier,
+	})
+	Expect(err).ToNot(HaveOccurred())
+	return dst
+}
@@ -0,0 +1,74 @@
+/*
+Copyright 2020 Backup Operator Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package consul
+
+import (
+	consulApi "github.com/hashicorp/consul/api"
+)
+
+// ConsulConf configures the connection of sources and destinations
+type ConsulConf struct {
+	// Address of Consul, optionally prefixed with the scheme, e.g.
+	// https://consul:8501
+	Address string
+	// Credentials for HTTP basic authentication
+	Username string
+	Password string
+	// ACL token
+	Token string
+	// Datacenter to use. If empty, the datacenter of the agent is used.
+	Datacenter string
+	// Allow followers to serve reads instead of only the leader
+	Stale bool
+	// Path to the CA bundle used to verify the server. If set, HTTPS is
+	// used.
+	CAFile string
+	// Paths to the client certificate and key
+	CertFile string
+	KeyFile  string
+	// Skip the verification of the server certificate
+	InsecureSkipVerify bool
+}
+
+func (c *ConsulConf) queryOptions() *consulApi.QueryOptions {
+	return &consulApi.QueryOptions{AllowStale: c.Stale}
+}
+
+func newClient(conf *ConsulConf) (*consulApi.Client, error) {
+	consulConf := consulApi.DefaultConfig()
+	consulConf.Address = conf.Address
+	if conf.Username != "" && conf.Password != "" {
+		consulConf.HttpAuth = &consulApi.HttpBasicAuth{
+			Username: conf.Username,
+			Password: conf.Password,
+		}
+	}
+	if conf.Token != "" {
+		consulConf.Token = conf.Token
+	}
+	if conf.Datacenter != "" {
+		consulConf.Datacenter = conf.Datacenter
+	}
+	if conf.CAFile != "" || conf.CertFile != "" || conf.InsecureSkipVerify {
+		consulConf.Scheme = "https"
+		consulConf.TLSConfig.CAFile = conf.CAFile
+		consulConf.TLSConfig.CertFile = conf.CertFile
+		consulConf.TLSConfig.KeyFile = conf.KeyFile
+		consulConf.TLSConfig.InsecureSkipVerify = conf.InsecureSkipVerify
+	}
+	return consulApi.NewClient(consulConf)
+}
@@ -28,16 +28,8 @@ type consulDestination struct {
 	log    logger.Logger
 }
 
-func NewConsulDestination(uri, username, password string) (backup.Destination, error) {
-	consulConf := consulApi.DefaultConfig()
-	consulConf.Address = uri
-	if username != "" && password != "" {
-		consulConf.HttpAuth = &consulApi.HttpBasicAuth{
-			Username: username,
-			Password: password,
-		}
-	}
-	client, err := consulApi.NewClient(consulConf)
+func NewConsulDestination(conf *ConsulConf) (backup.Destination, error) {
+	client, err := newClient(conf)
 	if err != nil {
 		return nil, err
 	}
@@ -23,10 +23,10 @@ import (
 
 var _ = Describe("ConsulDestination", func() {
 	It("should restore dump", func() {
-		src, err := NewConsulSource(srcURI, "", "", "test.snap")
+		src, err := NewConsulSource(&ConsulConf{Address: srcURI}, "test.snap")
 		Expect(err).ToNot(HaveOccurred())
 		Expect(src).ToNot(BeNil())
-		dst, err := NewConsulDestination(dstURI, "", "")
+		dst, err := NewConsulDestination(&ConsulConf{Address: dstURI})
 		Expect(err).ToNot(HaveOccurred())
 		Expect(dst).ToNot(BeNil())
 		_, err = src.Stream(dst)
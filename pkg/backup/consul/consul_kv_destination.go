@@ -0,0 +1,98 @@
+/*
+Copyright 2020 Backup Operator Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package consul
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/kubism/backup-operator/pkg/backup"
+	"github.com/kubism/backup-operator/pkg/logger"
+
+	consulApi "github.com/hashicorp/consul/api"
+)
+
+type consulKVDestination struct {
+	Prefixes []string
+	Client   *consulApi.Client
+	log      logger.Logger
+}
+
+// NewConsulKVDestination returns a destination importing JSON created by
+// `consul kv export` or NewConsulKVSource. Only keys under the provided
+// prefixes are imported, if any are provided. Other keys are not modified.
+func NewConsulKVDestination(conf *ConsulConf, prefixes []string) (backup.Destination, error) {
+	client, err := newClient(conf)
+	if err != nil {
+		return nil, err
+	}
+	return &consulKVDestination{
+		Prefixes: prefixes,
+		Client:   client,
+		log:      logger.WithName("consulkvdst"),
+	}, nil
+}
+
+func (s *consulKVDestination) Store(obj backup.Object) (int64, error) {
+	log := s.log
+
+	log.Info("restore starting")
+	cr := &backup.CountingReader{Reader: obj.Data}
+	var entries []kvEntry
+	if err := json.NewDecoder(cr).Decode(&entries); err != nil {
+		log.Error(err, "Failed to decode export")
+		return cr.N, err
+	}
+	numKeys := 0
+	for _, entry := range entries {
+		if !hasAnyPrefix(entry.Key, s.Prefixes) {
+			continue
+		}
+		value, err := base64.StdEncoding.DecodeString(entry.Value)
+		if err != nil {
+			return cr.N, fmt.Errorf("invalid value of key %s: %v", entry.Key, err)
+		}
+		_, err = s.Client.KV().Put(&consulApi.KVPair{
+			Key:   entry.Key,
+			Flags: entry.Flags,
+			Value: value,
+		}, &consulApi.WriteOptions{})
+		if err != nil {
+			log.Error(err, "Failed to write key to consul", "key", entry.Key)
+			return cr.N, err
+		}
+		numKeys++
+	}
+	log.Info("restore finished", "numKeys", numKeys)
+	return cr.N, nil
+}
+
+// hasAnyPrefix returns true if no prefixes are provided or the key has at
+// least one of them
+func hasAnyPrefix(key string, prefixes []string) bool {
+	if len(prefixes) == 0 {
+		return true
+	}
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,52 @@
+/*
+Copyright 2020 Backup Operator Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package consul
+
+import (
+	"github.com/kubism/backup-operator/pkg/backup/mem"
+	"github.com/kubism/backup-operator/pkg/testutil"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ConsulKVDestination", func() {
+	It("should import keys with prefixes", func() {
+		Expect(testutil.InsertConsulTestData(dstURI, map[string]string{
+			"app/config": "outdated",
+			"unrelated":  "untouched",
+		})).Should(Succeed())
+		export := []byte(`[
+	{"key": "app/config", "flags": 0, "value": "dmFsdWU="},
+	{"key": "app/new", "flags": 42, "value": ""},
+	{"key": "other/config", "flags": 0, "value": "dmFsdWU="}
+]`)
+		src, _ := mem.NewBufferSource("test.json", export)
+		dst, err := NewConsulKVDestination(&ConsulConf{Address: dstURI}, []string{"app/"})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(dst).ToNot(BeNil())
+		written, err := src.Stream(dst)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(written).To(Equal(int64(len(export))))
+		data, err := testutil.GetConsulTestData(dstURI)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(data).To(HaveKeyWithValue("app/config", "value"))
+		Expect(data).To(HaveKeyWithValue("app/new", ""))
+		Expect(data).To(HaveKeyWithValue("unrelated", "untouched"))
+		Expect(data).ToNot(HaveKey("other/config"))
+	})
+})
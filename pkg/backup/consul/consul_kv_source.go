@@ -0,0 +1,112 @@
+/*
+Copyright 2020 Backup Operator Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package consul
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"sort"
+
+	"github.com/kubism/backup-operator/pkg/backup"
+	"github.com/kubism/backup-operator/pkg/logger"
+
+	consulApi "github.com/hashicorp/consul/api"
+)
+
+// kvEntry is compatible with the entries of `consul kv export`
+type kvEntry struct {
+	Key   string `json:"key"`
+	Flags uint64 `json:"flags"`
+	Value string `json:"value"`
+}
+
+type consulKVSource struct {
+	ExportName string
+	Prefixes   []string
+	Client     *consulApi.Client
+	Conf       ConsulConf
+	log        logger.Logger
+}
+
+// NewConsulKVSource returns a source exporting all keys under the provided
+// prefixes as JSON compatible with `consul kv export`. If no prefixes are
+// provided, the whole KV store is exported.
+func NewConsulKVSource(conf *ConsulConf, prefixes []string, exportName string) (backup.Source, error) {
+	client, err := newClient(conf)
+	if err != nil {
+		return nil, err
+	}
+	if len(prefixes) == 0 {
+		prefixes = []string{""}
+	}
+	return &consulKVSource{
+		ExportName: exportName,
+		Prefixes:   prefixes,
+		Client:     client,
+		Conf:       *conf,
+		log:        logger.WithName("consulkvsrc"),
+	}, nil
+}
+
+func (s *consulKVSource) Stream(dst backup.Destination) (int64, error) {
+	log := s.log
+
+	entries, err := s.list()
+	if err != nil {
+		log.Error(err, "Could not list keys of consul")
+		return 0, err
+	}
+	return backup.StreamTo(dst, s.ExportName, func(w io.Writer) error {
+		log.Info("starting dump")
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "\t")
+		if err := enc.Encode(entries); err != nil {
+			return err
+		}
+		log.Info("finished dump", "numKeys", len(entries))
+		return nil
+	})
+}
+
+// list returns the entries of all prefixes sorted by key, while omitting
+// duplicates of overlapping prefixes
+func (s *consulKVSource) list() ([]kvEntry, error) {
+	seen := map[string]bool{}
+	entries := []kvEntry{}
+	for _, prefix := range s.Prefixes {
+		pairs, _, err := s.Client.KV().List(prefix, s.Conf.queryOptions())
+		if err != nil {
+			return nil, err
+		}
+		for _, pair := range pairs {
+			if seen[pair.Key] {
+				continue
+			}
+			seen[pair.Key] = true
+			entries = append(entries, kvEntry{
+				Key:   pair.Key,
+				Flags: pair.Flags,
+				Value: base64.StdEncoding.EncodeToString(pair.Value),
+			})
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Key < entries[j].Key
+	})
+	return entries, nil
+}
@@ -0,0 +1,70 @@
+/*
+Copyright 2020 Backup Operator Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package consul
+
+import (
+	"encoding/json"
+
+	"github.com/kubism/backup-operator/pkg/backup/mem"
+	"github.com/kubism/backup-operator/pkg/testutil"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ConsulKVSource", func() {
+	It("should export keys with prefixes", func() {
+		Expect(testutil.InsertConsulTestData(srcURI, map[string]string{
+			"app/config":   "value",
+			"app/nested/a": "b",
+			"other/config": "value",
+		})).Should(Succeed())
+		src, err := NewConsulKVSource(&ConsulConf{Address: srcURI}, []string{"app/", "app/nested/"}, "test.json")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(src).ToNot(BeNil())
+		dst, _ := mem.NewBufferDestination()
+		written, err := src.Stream(dst)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(written).To(BeNumerically(">", 0))
+		var entries []kvEntry
+		Expect(json.Unmarshal(dst.Data["test.json"], &entries)).Should(Succeed())
+		Expect(entries).To(Equal([]kvEntry{
+			{Key: "app/config", Value: "dmFsdWU="},
+			{Key: "app/nested/a", Value: "Yg=="},
+		}))
+	})
+	It("should export with stale reads", func() {
+		Expect(testutil.InsertConsulTestData(srcURI, map[string]string{
+			"stale/config": "value",
+		})).Should(Succeed())
+		src, err := NewConsulKVSource(&ConsulConf{Address: srcURI, Stale: true, Datacenter: "dc1"}, []string{"stale/"}, "test.json")
+		Expect(err).ToNot(HaveOccurred())
+		dst, _ := mem.NewBufferDestination()
+		_, err = src.Stream(dst)
+		Expect(err).ToNot(HaveOccurred())
+		var entries []kvEntry
+		Expect(json.Unmarshal(dst.Data["test.json"], &entries)).Should(Succeed())
+		Expect(entries).To(HaveLen(1))
+	})
+	It("should fail for an unknown datacenter", func() {
+		src, err := NewConsulKVSource(&ConsulConf{Address: srcURI, Datacenter: "unknown"}, nil, "test.json")
+		Expect(err).ToNot(HaveOccurred())
+		dst, _ := mem.NewBufferDestination()
+		_, err = src.Stream(dst)
+		Expect(err).To(HaveOccurred())
+	})
+})
@@ -0,0 +1,188 @@
+/*
+Copyright 2020 Backup Operator Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package consul
+
+import (
+	"archive/tar"
+	"bufio"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
+)
+
+const (
+	snapshotMetaFile  = "meta.json"
+	snapshotStateFile = "state.bin"
+	snapshotSumsFile  = "SHA256SUMS"
+)
+
+// SnapshotMeta is the metadata of a raft snapshot stored in meta.json
+type SnapshotMeta struct {
+	Version int
+	ID      string
+	Index   uint64
+	Term    uint64
+}
+
+// VerifySnapshot reads the gzipped archive of a consul snapshot and makes sure
+// it is complete and all files match the checksums stored in SHA256SUMS.
+func VerifySnapshot(r io.Reader) (*SnapshotMeta, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("invalid snapshot: %v", err)
+	}
+	defer gz.Close()
+	var meta *SnapshotMeta
+	var expected map[string]string
+	computed := map[string]string{}
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid snapshot: %v", err)
+		}
+		switch hdr.Name {
+		case snapshotMetaFile:
+			h := sha256.New()
+			meta = &SnapshotMeta{}
+			if err := json.NewDecoder(io.TeeReader(tr, h)).Decode(meta); err != nil {
+				return nil, fmt.Errorf("invalid %s: %v", snapshotMetaFile, err)
+			}
+			if _, err := io.Copy(h, tr); err != nil {
+				return nil, err
+			}
+			computed[hdr.Name] = hex.EncodeToString(h.Sum(nil))
+		case snapshotSumsFile:
+			if expected, err = parseSums(tr); err != nil {
+				return nil, err
+			}
+		default:
+			h := sha256.New()
+			if _, err := io.Copy(h, tr); err != nil {
+				return nil, fmt.Errorf("invalid snapshot: %v", err)
+			}
+			computed[hdr.Name] = hex.EncodeToString(h.Sum(nil))
+		}
+	}
+	// read the remaining padding to verify the checksum of gzip as well
+	if _, err := io.Copy(ioutil.Discard, gz); err != nil {
+		return nil, fmt.Errorf("invalid snapshot: %v", err)
+	}
+	if meta == nil {
+		return nil, fmt.Errorf("snapshot is missing %s", snapshotMetaFile)
+	}
+	if _, ok := computed[snapshotStateFile]; !ok {
+		return nil, fmt.Errorf("snapshot is missing %s", snapshotStateFile)
+	}
+	if expected == nil {
+		return nil, fmt.Errorf("snapshot is missing %s", snapshotSumsFile)
+	}
+	for name, sum := range computed {
+		if expected[name] != sum {
+			return nil, fmt.Errorf("checksum of %s does not match", name)
+		}
+	}
+	for name := range expected {
+		if _, ok := computed[name]; !ok {
+			return nil, fmt.Errorf("snapshot is missing %s", name)
+		}
+	}
+	return meta, nil
+}
+
+// parseSums parses lines in the format of sha256sum
+func parseSums(r io.Reader) (map[string]string, error) {
+	sums := map[string]string{}
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("invalid line in %s: %s", snapshotSumsFile, line)
+		}
+		sums[fields[1]] = fields[0]
+	}
+	return sums, scanner.Err()
+}
+
+// verifyingReader passes the snapshot through, while it is verified in
+// parallel. Instead of io.EOF the error of the verification is returned, so
+// destinations abort storing incomplete snapshots.
+type verifyingReader struct {
+	r    io.Reader
+	pw   *io.PipeWriter
+	done chan error
+	meta *SnapshotMeta
+	err  error
+}
+
+func newVerifyingReader(r io.Reader) *verifyingReader {
+	pr, pw := io.Pipe()
+	v := &verifyingReader{
+		r:    io.TeeReader(r, pw),
+		pw:   pw,
+		done: make(chan error, 1),
+	}
+	go func() {
+		meta, err := VerifySnapshot(pr)
+		io.Copy(ioutil.Discard, pr) // make sure the tee is never blocked
+		v.meta = meta
+		v.done <- err
+	}()
+	return v
+}
+
+func (v *verifyingReader) Read(p []byte) (int, error) {
+	if v.done == nil {
+		return 0, v.err
+	}
+	n, err := v.r.Read(p)
+	if err == nil {
+		return n, nil
+	}
+	v.err = err
+	if err == io.EOF {
+		v.pw.Close()
+		if verr := <-v.done; verr != nil {
+			v.err = verr
+		}
+	} else {
+		v.pw.CloseWithError(err)
+		<-v.done
+	}
+	v.done = nil
+	return n, v.err
+}
+
+// Meta returns the metadata of the snapshot once it was read completely
+func (v *verifyingReader) Meta() *SnapshotMeta {
+	if v.done != nil {
+		return nil
+	}
+	return v.meta
+}
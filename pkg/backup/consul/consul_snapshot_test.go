@@ -0,0 +1,101 @@
+/*
+Copyright 2020 Backup Operator Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package consul
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"fmt"
+	"io/ioutil"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// createSnapshot returns an archive in the format of consul snapshots with
+// the provided sums overriding the computed checksums
+func createSnapshot(files map[string]string, sums map[string]string) []byte {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	var lines bytes.Buffer
+	for _, name := range []string{snapshotMetaFile, snapshotStateFile} {
+		data, ok := files[name]
+		if !ok {
+			continue
+		}
+		Expect(tw.WriteHeader(&tar.Header{Name: name, Mode: 0600, Size: int64(len(data))})).To(Succeed())
+		_, err := tw.Write([]byte(data))
+		Expect(err).ToNot(HaveOccurred())
+		sum, ok := sums[name]
+		if !ok {
+			sum = fmt.Sprintf("%x", sha256.Sum256([]byte(data)))
+		}
+		fmt.Fprintf(&lines, "%s  %s\n", sum, name)
+	}
+	Expect(tw.WriteHeader(&tar.Header{Name: snapshotSumsFile, Mode: 0600, Size: int64(lines.Len())})).To(Succeed())
+	_, err := tw.Write(lines.Bytes())
+	Expect(err).ToNot(HaveOccurred())
+	Expect(tw.Close()).To(Succeed())
+	Expect(gz.Close()).To(Succeed())
+	return buf.Bytes()
+}
+
+var _ = Describe("VerifySnapshot", func() {
+	files := map[string]string{
+		snapshotMetaFile:  `{"Version":1,"ID":"2-42-1600000000000","Index":42,"Term":2}`,
+		snapshotStateFile: "state",
+	}
+
+	It("should return the metadata of valid snapshots", func() {
+		meta, err := VerifySnapshot(bytes.NewReader(createSnapshot(files, nil)))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(meta).To(Equal(&SnapshotMeta{Version: 1, ID: "2-42-1600000000000", Index: 42, Term: 2}))
+	})
+	It("should reject mismatching checksums", func() {
+		_, err := VerifySnapshot(bytes.NewReader(createSnapshot(files, map[string]string{
+			snapshotStateFile: fmt.Sprintf("%x", sha256.Sum256([]byte("other"))),
+		})))
+		Expect(err).To(MatchError(ContainSubstring("checksum of state.bin")))
+	})
+	It("should reject incomplete snapshots", func() {
+		_, err := VerifySnapshot(bytes.NewReader(createSnapshot(map[string]string{
+			snapshotMetaFile: files[snapshotMetaFile],
+		}, nil)))
+		Expect(err).To(MatchError(ContainSubstring("missing state.bin")))
+		data := createSnapshot(files, nil)
+		_, err = VerifySnapshot(bytes.NewReader(data[:len(data)/2]))
+		Expect(err).To(HaveOccurred())
+	})
+	It("should fail reading truncated snapshots", func() {
+		data := createSnapshot(files, nil)
+		vr := newVerifyingReader(bytes.NewReader(data[:len(data)-10]))
+		_, err := ioutil.ReadAll(vr)
+		Expect(err).To(HaveOccurred())
+		Expect(vr.Meta()).To(BeNil())
+	})
+	It("should pass valid snapshots through", func() {
+		data := createSnapshot(files, nil)
+		vr := newVerifyingReader(bytes.NewReader(data))
+		read, err := ioutil.ReadAll(vr)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(read).To(Equal(data))
+		Expect(vr.Meta().Index).To(Equal(uint64(42)))
+	})
+})
@@ -17,9 +17,7 @@ limitations under the License.
 package consul
 
 import (
-	"fmt"
 	"io"
-	"time"
 
 	"github.com/kubism/backup-operator/pkg/backup"
 	"github.com/kubism/backup-operator/pkg/logger"
@@ -30,19 +28,19 @@ import (
 type consulSource struct {
 	SnapName string
 	Client   *consulApi.Client
+	Conf     ConsulConf
+	meta     *SnapshotMeta
 	log      logger.Logger
 }
 
-func NewConsulSource(uri, username, password, snapName string) (backup.Source, error) {
-	consulConf := consulApi.DefaultConfig()
-	consulConf.Address = uri
-	if username != "" && password != "" {
-		consulConf.HttpAuth = &consulApi.HttpBasicAuth{
-			Username: username,
-			Password: password,
-		}
-	}
-	client, err := consulApi.NewClient(consulConf)
+// SnapshotMetaProvider is implemented by sources, which report the metadata
+// of the last snapshot
+type SnapshotMetaProvider interface {
+	SnapshotMeta() *SnapshotMeta
+}
+
+func NewConsulSource(conf *ConsulConf, snapName string) (backup.Source, error) {
+	client, err := newClient(conf)
 	if err != nil {
 		return nil, err
 	}
@@ -50,6 +48,7 @@ func NewConsulSource(uri, username, password, snapName string) (backup.Source, e
 	return &consulSource{
 		SnapName: snapName,
 		Client:   client,
+		Conf:     *conf,
 		log:      logger.WithName("consulsrc"),
 	}, nil
 }
@@ -57,35 +56,26 @@ func NewConsulSource(uri, username, password, snapName string) (backup.Source, e
 func (s *consulSource) Stream(dst backup.Destination) (int64, error) {
 	log := s.log
 
-	reader, _, err := s.Client.Snapshot().Save(&consulApi.QueryOptions{})
+	reader, _, err := s.Client.Snapshot().Save(s.Conf.queryOptions())
 	if err != nil {
 		log.Error(err, "Could not get snapshot from consul")
 		return 0, err
 	}
 	defer reader.Close()
-	pr, pw := io.Pipe()
-
-	// start the backup in a separate routine
-	errc := make(chan error, 1)
-	defer close(errc)
-	go func() {
-		defer pw.Close()
+	// incomplete snapshots fail the destination instead of being stored
+	vr := newVerifyingReader(reader)
+	return backup.StreamTo(dst, s.SnapName, func(w io.Writer) error {
 		log.Info("starting dump")
-		numBytes, err := io.Copy(pw, reader)
+		numBytes, err := io.Copy(w, vr)
 		if err != nil {
-			errc <- err
+			return err
 		}
-		log.Info("finished dump", "numBytes", numBytes)
-	}()
-	written, dsterr := dst.Store(backup.Object{
-		ID:   s.SnapName,
-		Data: pr,
+		s.meta = vr.Meta()
+		log.Info("finished dump", "numBytes", numBytes, "index", s.meta.Index, "term", s.meta.Term)
+		return nil
 	})
+}
 
-	select {
-	case srcerr := <-errc: // return src error if possible as well
-		return written, fmt.Errorf("dst error: %v; src error: %v", dsterr, srcerr)
-	case <-time.After(1 * time.Second):
-		return written, dsterr
-	}
+func (s *consulSource) SnapshotMeta() *SnapshotMeta {
+	return s.meta
 }
@@ -0,0 +1,61 @@
+/*
+Copyright 2020 Backup Operator Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package etcd
+
+import (
+	"crypto/tls"
+	"time"
+
+	"go.etcd.io/etcd/client/pkg/v3/transport"
+	clientv3 "go.etcd.io/etcd/client/v3"
+)
+
+const DefaultDialTimeout = 10 * time.Second
+
+type EtcdConf struct {
+	Endpoints []string
+	Username  string
+	Password  string
+	// Paths of the PEM encoded certificates used for TLS. If none are
+	// provided, TLS is disabled.
+	CAFile   string
+	CertFile string
+	KeyFile  string
+}
+
+func newClient(conf *EtcdConf) (*clientv3.Client, error) {
+	var tlsConf *tls.Config
+	if conf.CAFile != "" || conf.CertFile != "" || conf.KeyFile != "" {
+		tlsInfo := transport.TLSInfo{
+			TrustedCAFile: conf.CAFile,
+			CertFile:      conf.CertFile,
+			KeyFile:       conf.KeyFile,
+		}
+		var err error
+		tlsConf, err = tlsInfo.ClientConfig()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return clientv3.New(clientv3.Config{
+		Endpoints:   conf.Endpoints,
+		Username:    conf.Username,
+		Password:    conf.Password,
+		TLS:         tlsConf,
+		DialTimeout: DefaultDialTimeout,
+	})
+}
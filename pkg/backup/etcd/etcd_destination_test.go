@@ -0,0 +1,68 @@
+/*
+Copyright 2020 Backup Operator Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package etcd
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/kubism/backup-operator/pkg/backup/fs"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("EtcdDestination", func() {
+	It("should restore snapshot into data directory", func() {
+		src, err := NewEtcdSource(&EtcdConf{
+			Endpoints: []string{srcEndpoint},
+		}, "backup.db")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(src).ToNot(BeNil())
+		dir, err := ioutil.TempDir("", "etcddst")
+		Expect(err).ToNot(HaveOccurred())
+		defer os.RemoveAll(dir)
+		dataDir := filepath.Join(dir, "data")
+		dst, err := NewEtcdDestination(&EtcdDestinationConf{
+			DataDir: dataDir,
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(dst).ToNot(BeNil())
+		written, err := src.Stream(dst)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(written).To(BeNumerically(">", 0))
+		Expect(filepath.Join(dataDir, "member", "snap", "db")).Should(BeAnExistingFile())
+		Expect(filepath.Join(dataDir, "member", "wal")).Should(BeADirectory())
+	})
+	It("should refuse corrupt snapshot", func() {
+		dir, err := ioutil.TempDir("", "etcddst")
+		Expect(err).ToNot(HaveOccurred())
+		defer os.RemoveAll(dir)
+		fp := filepath.Join(dir, "backup.db")
+		err = ioutil.WriteFile(fp, make([]byte, 512+32), 0644)
+		Expect(err).ToNot(HaveOccurred())
+		src, err := fs.NewFileSource(fp)
+		Expect(err).ToNot(HaveOccurred())
+		dst, err := NewEtcdDestination(&EtcdDestinationConf{
+			DataDir: filepath.Join(dir, "data"),
+		})
+		Expect(err).ToNot(HaveOccurred())
+		_, err = src.Stream(dst)
+		Expect(err).To(HaveOccurred())
+	})
+})
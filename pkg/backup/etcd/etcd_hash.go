@@ -0,0 +1,61 @@
+/*
+Copyright 2020 Backup Operator Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package etcd
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"errors"
+	"hash"
+	"io"
+)
+
+// ErrHashMismatch is returned if the checksum of a snapshot is missing or
+// does not match its content
+var ErrHashMismatch = errors.New("snapshot hash mismatch")
+
+// hashReader verifies the sha256 checksum etcd appends to every snapshot
+// while reading. The checksum is passed through unmodified as it is required
+// for restoring the snapshot again.
+type hashReader struct {
+	r    io.Reader
+	h    hash.Hash
+	tail []byte
+}
+
+func newHashReader(r io.Reader) *hashReader {
+	return &hashReader{
+		r: r,
+		h: sha256.New(),
+	}
+}
+
+func (h *hashReader) Read(p []byte) (int, error) {
+	n, err := h.r.Read(p)
+	if n > 0 {
+		// hold back the last bytes as they might be the checksum
+		h.tail = append(h.tail, p[:n]...)
+		if k := len(h.tail) - sha256.Size; k > 0 {
+			h.h.Write(h.tail[:k])
+			h.tail = append(h.tail[:0], h.tail[k:]...)
+		}
+	}
+	if err == io.EOF && !bytes.Equal(h.h.Sum(nil), h.tail) {
+		return n, ErrHashMismatch
+	}
+	return n, err
+}
@@ -0,0 +1,66 @@
+/*
+Copyright 2020 Backup Operator Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package etcd
+
+import (
+	"context"
+	"io"
+
+	"github.com/kubism/backup-operator/pkg/backup"
+	"github.com/kubism/backup-operator/pkg/logger"
+
+	clientv3 "go.etcd.io/etcd/client/v3"
+)
+
+type etcdSource struct {
+	SnapName string
+	Client   *clientv3.Client
+	log      logger.Logger
+}
+
+func NewEtcdSource(conf *EtcdConf, snapName string) (backup.Source, error) {
+	client, err := newClient(conf)
+	if err != nil {
+		return nil, err
+	}
+	return &etcdSource{
+		SnapName: snapName,
+		Client:   client,
+		log:      logger.WithName("etcdsrc"),
+	}, nil
+}
+
+func (s *etcdSource) Stream(dst backup.Destination) (int64, error) {
+	log := s.log
+	defer s.Client.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	reader, err := s.Client.Snapshot(ctx)
+	if err != nil {
+		log.Error(err, "Could not get snapshot from etcd")
+		return 0, err
+	}
+	defer reader.Close()
+	return backup.StreamTo(dst, s.SnapName, func(w io.Writer) error {
+		log.Info("starting dump")
+		// the hash is verified at the end, so corrupt snapshots are never stored
+		numBytes, err := io.Copy(w, newHashReader(reader))
+		log.Info("finished dump", "numBytes", numBytes)
+		return err
+	})
+}
@@ -0,0 +1,66 @@
+/*
+Copyright 2020 Backup Operator Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package etcd
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/kubism/backup-operator/pkg/backup/fs"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("EtcdSource", func() {
+	It("should dump to file", func() {
+		src, err := NewEtcdSource(&EtcdConf{
+			Endpoints: []string{srcEndpoint},
+		}, "backup.db")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(src).ToNot(BeNil())
+		dir, err := ioutil.TempDir("", "etcdsrc")
+		Expect(err).ToNot(HaveOccurred())
+		defer os.RemoveAll(dir)
+		fp := filepath.Join(dir, "backup.db")
+		dst, err := fs.NewDirDestination(dir)
+		Expect(err).ToNot(HaveOccurred())
+		written, err := src.Stream(dst)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(written).To(BeNumerically(">", 0))
+		Expect(fp).Should(BeAnExistingFile())
+		fi, err := os.Stat(fp)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(fi.Size()).Should(Equal(written))
+	})
+	It("should verify the snapshot hash", func() {
+		data := []byte("snapshot")
+		sum := sha256.Sum256(data)
+		valid := append(append([]byte{}, data...), sum[:]...)
+		read, err := ioutil.ReadAll(newHashReader(bytes.NewReader(valid)))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(read).To(Equal(valid))
+		corrupt := append([]byte("corrupt!"), sum[:]...)
+		_, err = ioutil.ReadAll(newHashReader(bytes.NewReader(corrupt)))
+		Expect(err).To(Equal(ErrHashMismatch))
+		_, err = ioutil.ReadAll(newHashReader(bytes.NewReader(data)))
+		Expect(err).To(Equal(ErrHashMismatch))
+	})
+})
@@ -0,0 +1,75 @@
+/*
+Copyright 2020 Backup Operator Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package etcd
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/kubism/backup-operator/pkg/logger"
+	"github.com/kubism/backup-operator/pkg/testutil"
+	"github.com/onsi/ginkgo/reporters"
+	"github.com/ory/dockertest/v3"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var (
+	pool        *dockertest.Pool
+	srcResource *dockertest.Resource
+	srcEndpoint string
+)
+
+func TestEtcd(t *testing.T) {
+	RegisterFailHandler(Fail)
+	junitReporter := reporters.NewJUnitReporter("../../../reports/etcd-junit.xml")
+	RunSpecsWithDefaultAndCustomReporters(t, "Etcd", []Reporter{junitReporter})
+}
+
+var _ = BeforeSuite(func(done Done) {
+	var err error
+	log := logger.WithName("etcdsetup")
+	By("bootstrapping etcd")
+	pool, err = dockertest.NewPool("")
+	Expect(err).ToNot(HaveOccurred())
+	log.Info("spawn src etcd container")
+	srcResource, err = pool.RunWithOptions(&dockertest.RunOptions{
+		Repository: "gcr.io/etcd-development/etcd",
+		Tag:        "v3.5.2",
+		Cmd: []string{"/usr/local/bin/etcd",
+			"--listen-client-urls", "http://0.0.0.0:2379",
+			"--advertise-client-urls", "http://localhost:2379",
+		},
+	})
+	Expect(err).ToNot(HaveOccurred())
+	srcEndpoint = fmt.Sprintf("localhost:%s", srcResource.GetPort("2379/tcp"))
+	log.Info("check src etcd connection", "endpoint", srcEndpoint)
+	err = testutil.WaitForEtcd(pool, srcEndpoint)
+	Expect(err).ToNot(HaveOccurred())
+	log.Info("insert test data", "endpoint", srcEndpoint)
+	err = testutil.InsertEtcdTestData(srcEndpoint)
+	Expect(err).ToNot(HaveOccurred())
+	log.Info("etcd ready")
+	close(done)
+}, 60)
+
+var _ = AfterSuite(func() {
+	By("tearing down the test environment")
+	err := pool.Purge(srcResource)
+	Expect(err).ToNot(HaveOccurred())
+})
@@ -0,0 +1,100 @@
+/*
+Copyright 2020 Backup Operator Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package exec
+
+import (
+	"fmt"
+	"io"
+	"os/exec"
+
+	"github.com/kubism/backup-operator/pkg/backup"
+	"github.com/kubism/backup-operator/pkg/logger"
+)
+
+// StderrTailSize is the number of trailing bytes of stderr, which are kept to
+// be included in errors
+const StderrTailSize = 4096
+
+// NewExecSource returns a source, which runs the provided command and uses
+// its stdout as backup. A non-zero exit code fails the backup.
+func NewExecSource(command []string, archiveName string) (backup.Source, error) {
+	if len(command) == 0 {
+		return nil, fmt.Errorf("command is required")
+	}
+	return &execSource{
+		Command:     command,
+		ArchiveName: archiveName,
+		log:         logger.WithName("execsrc"),
+	}, nil
+}
+
+type execSource struct {
+	Command     []string
+	ArchiveName string
+	log         logger.Logger
+}
+
+func (s *execSource) Stream(dst backup.Destination) (int64, error) {
+	log := s.log
+	cmd := exec.Command(s.Command[0], s.Command[1:]...)
+	stderr := &tailBuffer{Size: StderrTailSize}
+	cmd.Stderr = stderr
+	log.Info("start storing dump")
+	return backup.StreamTo(dst, s.ArchiveName, func(w io.Writer) error {
+		log.Info("starting dump")
+		if err := backup.RunCommand(cmd, w); err != nil {
+			log.Error(err, "Command failed", "stderr", stderr.String())
+			exitCode := -1
+			if exitErr, ok := err.(*exec.ExitError); ok {
+				exitCode = exitErr.ExitCode()
+			}
+			return &CommandError{ExitCode: exitCode, Stderr: stderr.String(), Err: err}
+		}
+		log.Info("finished dump")
+		return nil
+	})
+}
+
+// CommandError is returned, if the command fails. The exit code is -1, if
+// the command did not exit by itself, e.g. because it was not found.
+type CommandError struct {
+	ExitCode int
+	Stderr   string
+	Err      error
+}
+
+func (e *CommandError) Error() string {
+	return fmt.Sprintf("%v: %s", e.Err, e.Stderr)
+}
+
+// tailBuffer only keeps the last Size bytes written to it
+type tailBuffer struct {
+	Size int
+	buf  []byte
+}
+
+func (b *tailBuffer) Write(p []byte) (int, error) {
+	b.buf = append(b.buf, p...)
+	if len(b.buf) > b.Size {
+		b.buf = b.buf[len(b.buf)-b.Size:]
+	}
+	return len(p), nil
+}
+
+func (b *tailBuffer) String() string {
+	return string(b.buf)
+}
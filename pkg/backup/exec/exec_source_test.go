@@ -0,0 +1,78 @@
+/*
+Copyright 2020 Backup Operator Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package exec
+
+import (
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/kubism/backup-operator/pkg/backup/fs"
+	"github.com/kubism/backup-operator/pkg/backup/mem"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ExecSource", func() {
+	It("should store stdout", func() {
+		src, err := NewExecSource([]string{"sh", "-c", "echo hello; echo ignored >&2"}, "test.out")
+		Expect(err).ToNot(HaveOccurred())
+		dst, _ := mem.NewBufferDestination()
+		written, err := src.Stream(dst)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(written).To(Equal(int64(6)))
+		Expect(string(dst.Data["test.out"])).To(Equal("hello\n"))
+	})
+	It("should fail with tail of stderr on non-zero exit code", func() {
+		src, err := NewExecSource([]string{"sh", "-c", "head -c 8192 /dev/zero | tr '\\0' a >&2; echo failed >&2; exit 3"}, "test.out")
+		Expect(err).ToNot(HaveOccurred())
+		dst, _ := mem.NewBufferDestination()
+		_, err = src.Stream(dst)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("exit status 3"))
+		Expect(err).To(BeAssignableToTypeOf(&CommandError{}))
+		Expect(err.(*CommandError).ExitCode).To(Equal(3))
+		Expect(err.Error()).To(ContainSubstring("failed"))
+		Expect(len(err.Error())).To(BeNumerically("<", StderrTailSize+100))
+	})
+	It("should not store partial output on failure", func() {
+		dir, err := ioutil.TempDir("", "execsrc")
+		Expect(err).ToNot(HaveOccurred())
+		defer os.RemoveAll(dir)
+		src, err := NewExecSource([]string{"sh", "-c", "echo partial; echo boom >&2; exit 3"}, "test.out")
+		Expect(err).ToNot(HaveOccurred())
+		dst, err := fs.NewDirDestination(dir)
+		Expect(err).ToNot(HaveOccurred())
+		_, err = src.Stream(dst)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("boom"))
+		Expect(ioutil.ReadDir(dir)).To(BeEmpty())
+	})
+	It("should require a command", func() {
+		_, err := NewExecSource(nil, "test.out")
+		Expect(err).To(HaveOccurred())
+	})
+	It("should fail if command does not exist", func() {
+		src, err := NewExecSource([]string{"/does/not/exist"}, "test.out")
+		Expect(err).ToNot(HaveOccurred())
+		dst, _ := mem.NewBufferDestination()
+		_, err = src.Stream(dst)
+		Expect(err).To(HaveOccurred())
+		Expect(strings.Contains(err.Error(), "no such file")).To(BeTrue())
+	})
+})
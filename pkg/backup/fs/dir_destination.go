@@ -17,29 +17,143 @@ limitations under the License.
 package fs
 
 import (
+	"fmt"
 	"io"
+	"io/ioutil"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
 
 	"github.com/kubism/backup-operator/pkg/backup"
 )
 
-func NewDirDestination(dir string) (backup.Destination, error) {
-	return &dirDestination{
+// temporarySuffix of files, which are still being written
+const temporarySuffix = ".tmp"
+
+// NewDirDestination returns a destination storing objects as files below the
+// directory, which is created if it does not exist
+func NewDirDestination(dir string) (*DirDestination, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+	return &DirDestination{
 		dir: dir,
 	}, nil
 }
 
-type dirDestination struct {
+type DirDestination struct {
 	dir string
 }
 
-func (f *dirDestination) Store(obj backup.Object) (int64, error) {
+// Store writes the object to a temporary file, which is synced and renamed
+// after it was written completely, so incomplete backups are never visible
+func (f *DirDestination) Store(obj backup.Object) (int64, error) {
 	fp := filepath.Join(f.dir, obj.ID)
-	file, err := os.Create(fp)
+	if err := os.MkdirAll(filepath.Dir(fp), 0755); err != nil {
+		return 0, err
+	}
+	file, err := ioutil.TempFile(filepath.Dir(fp), fmt.Sprintf(".%s.*%s", filepath.Base(fp), temporarySuffix))
 	if err != nil {
 		return 0, err
 	}
-	defer file.Close()
-	return io.Copy(file, obj.Data)
+	written, err := io.Copy(file, obj.Data)
+	if err == nil {
+		err = file.Sync()
+	}
+	if cerr := file.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil {
+		err = os.Rename(file.Name(), fp)
+	}
+	if err != nil {
+		os.Remove(file.Name()) // nolint:errcheck
+		return written, err
+	}
+	return written, syncDir(filepath.Dir(fp))
+}
+
+// syncDir makes sure renames within the directory are persisted
+func syncDir(dir string) error {
+	d, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	defer d.Close()
+	return d.Sync()
+}
+
+type file struct {
+	name string
+	info os.FileInfo
+}
+
+// files returns all completely written files below the directory relative
+// to it
+func (f *DirDestination) files() ([]file, error) {
+	files := []file{}
+	err := filepath.Walk(f.dir, func(fp string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() || strings.HasSuffix(info.Name(), temporarySuffix) {
+			return nil
+		}
+		name, err := filepath.Rel(f.dir, fp)
+		if err != nil {
+			return err
+		}
+		files = append(files, file{name: filepath.ToSlash(name), info: info})
+		return nil
+	})
+	return files, err
+}
+
+// EnsureRetention deletes all but the newest max files of the directory
+func (f *DirDestination) EnsureRetention(max int) error {
+	files, err := f.files()
+	if err != nil {
+		return err
+	}
+	if len(files) <= max {
+		return nil
+	}
+	sort.Slice(files, func(i, j int) bool {
+		ti, tj := files[i].info.ModTime(), files[j].info.ModTime()
+		if ti.Equal(tj) {
+			return files[i].name > files[j].name // names usually contain the time
+		}
+		return ti.After(tj)
+	})
+	names := make([]string, 0, len(files)-max)
+	for _, file := range files[max:] {
+		names = append(names, file.name)
+	}
+	return f.DeleteObjects(names)
+}
+
+// ListObjectNames returns the names of all files of the destination
+// relative to its directory
+func (f *DirDestination) ListObjectNames() ([]string, error) {
+	files, err := f.files()
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(files))
+	for _, file := range files {
+		names = append(names, file.name)
+	}
+	return names, nil
+}
+
+// DeleteObjects deletes the files with the provided names relative to the
+// directory of the destination
+func (f *DirDestination) DeleteObjects(names []string) error {
+	for _, name := range names {
+		if err := os.Remove(filepath.Join(f.dir, filepath.FromSlash(name))); err != nil {
+			return err
+		}
+	}
+	return nil
 }
@@ -18,11 +18,14 @@ package fs
 
 import (
 	"bytes"
+	"fmt"
+	"io"
 	"io/ioutil"
 	"os"
 	"path/filepath"
 
 	"github.com/kubism/backup-operator/pkg/backup"
+	"github.com/kubism/backup-operator/pkg/backup/mem"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -48,4 +51,77 @@ var _ = Describe("FileDestination", func() {
 		Expect(err).ToNot(HaveOccurred())
 		Expect(res).Should(Equal(data))
 	})
+	It("should create directories", func() {
+		dir, err := ioutil.TempDir("", "fdst")
+		Expect(err).ToNot(HaveOccurred())
+		defer os.RemoveAll(dir)
+		dst, err := NewDirDestination(filepath.Join(dir, "ns/plan"))
+		Expect(err).ToNot(HaveOccurred())
+		src, _ := mem.NewBufferSource("oplog/1-2.bson.gz", []byte("temporarycontent"))
+		_, err = src.Stream(dst)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(filepath.Join(dir, "ns/plan/oplog/1-2.bson.gz")).Should(BeARegularFile())
+	})
+	It("should not leave incomplete files", func() {
+		dir, err := ioutil.TempDir("", "fdst")
+		Expect(err).ToNot(HaveOccurred())
+		defer os.RemoveAll(dir)
+		dst, err := NewDirDestination(dir)
+		Expect(err).ToNot(HaveOccurred())
+		_, err = dst.Store(backup.Object{
+			ID:   "incomplete",
+			Data: io.MultiReader(bytes.NewBufferString("temporarycontent"), failingReader{}),
+		})
+		Expect(err).To(HaveOccurred())
+		Expect(ioutil.ReadDir(dir)).To(BeEmpty())
+	})
+	It("should replace existing files", func() {
+		dir, err := ioutil.TempDir("", "fdst")
+		Expect(err).ToNot(HaveOccurred())
+		defer os.RemoveAll(dir)
+		dst, err := NewDirDestination(dir)
+		Expect(err).ToNot(HaveOccurred())
+		for _, content := range []string{"first", "second"} {
+			src, _ := mem.NewBufferSource("replaced", []byte(content))
+			_, err := src.Stream(dst)
+			Expect(err).ToNot(HaveOccurred())
+		}
+		Expect(ioutil.ReadFile(filepath.Join(dir, "replaced"))).To(Equal([]byte("second")))
+		Expect(dst.ListObjectNames()).To(Equal([]string{"replaced"}))
+	})
+	It("should ensure retention", func() {
+		dir, err := ioutil.TempDir("", "fdst")
+		Expect(err).ToNot(HaveOccurred())
+		defer os.RemoveAll(dir)
+		dst, err := NewDirDestination(dir)
+		Expect(err).ToNot(HaveOccurred())
+		for _, id := range []string{"backup-1", "backup-2", "backup-3", "backup-4"} {
+			src, _ := mem.NewBufferSource(id, []byte(id))
+			_, err := src.Stream(dst)
+			Expect(err).ToNot(HaveOccurred())
+		}
+		Expect(dst.EnsureRetention(2)).To(Succeed())
+		Expect(dst.ListObjectNames()).To(ConsistOf("backup-3", "backup-4"))
+	})
+	It("should list and delete objects relative to the directory", func() {
+		dir, err := ioutil.TempDir("", "fdst")
+		Expect(err).ToNot(HaveOccurred())
+		defer os.RemoveAll(dir)
+		dst, err := NewDirDestination(dir)
+		Expect(err).ToNot(HaveOccurred())
+		for _, id := range []string{"backup-1", "oplog/1-2.bson.gz"} {
+			src, _ := mem.NewBufferSource(id, []byte(id))
+			_, err := src.Stream(dst)
+			Expect(err).ToNot(HaveOccurred())
+		}
+		Expect(dst.ListObjectNames()).To(ConsistOf("backup-1", "oplog/1-2.bson.gz"))
+		Expect(dst.DeleteObjects([]string{"oplog/1-2.bson.gz"})).To(Succeed())
+		Expect(dst.ListObjectNames()).To(Equal([]string{"backup-1"}))
+	})
 })
+
+type failingReader struct{}
+
+func (failingReader) Read(p []byte) (int, error) {
+	return 0, fmt.Errorf("broken source")
+}
@@ -0,0 +1,199 @@
+/*
+Copyright 2020 Backup Operator Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fs
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/kubism/backup-operator/pkg/backup"
+	"github.com/kubism/backup-operator/pkg/logger"
+)
+
+// NewDirSource creates a source streaming a tar.gz archive of the directory.
+// Permissions, ownership and symlinks are preserved. Patterns of include and
+// exclude use the syntax of path.Match and are matched against the slash
+// separated path relative to the directory, including all of its parents.
+// Patterns without a slash are matched against every path element instead,
+// e.g. "*.log" matches all log files and "cache" all directories named cache.
+// If include is empty, everything not excluded is archived.
+func NewDirSource(dir, archiveName string, include, exclude []string) (backup.Source, error) {
+	for _, pattern := range append(append([]string{}, include...), exclude...) {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("invalid pattern %q: %v", pattern, err)
+		}
+	}
+	return &dirSource{
+		dir:         dir,
+		archiveName: archiveName,
+		include:     include,
+		exclude:     exclude,
+		log:         logger.WithName("dirsrc"),
+	}, nil
+}
+
+type dirSource struct {
+	dir         string
+	archiveName string
+	include     []string
+	exclude     []string
+	log         logger.Logger
+}
+
+func (d *dirSource) Stream(dst backup.Destination) (int64, error) {
+	log := d.log
+	fi, err := os.Stat(d.dir)
+	if err != nil {
+		return 0, err
+	}
+	if !fi.IsDir() {
+		return 0, fmt.Errorf("%s is not a directory", d.dir)
+	}
+	return backup.StreamTo(dst, d.archiveName, func(w io.Writer) error {
+		log.Info("starting archive", "dir", d.dir)
+		numFiles, err := d.archive(w)
+		if err != nil {
+			return err
+		}
+		log.Info("finished archive", "numFiles", numFiles)
+		return nil
+	})
+}
+
+func (d *dirSource) archive(w io.Writer) (int, error) {
+	gw := gzip.NewWriter(w)
+	tw := tar.NewWriter(gw)
+	numFiles := 0
+	// headers of directories, which are not included themselves, but are
+	// archived before included content to preserve their mode and ownership
+	parents := map[string]*tar.Header{}
+	writeParents := func(name string) error {
+		dirs := []string{}
+		for dir := path.Dir(name); dir != "."; dir = path.Dir(dir) {
+			dirs = append(dirs, dir)
+		}
+		for i := len(dirs) - 1; i >= 0; i-- {
+			hdr, ok := parents[dirs[i]]
+			if !ok {
+				continue
+			}
+			if err := tw.WriteHeader(hdr); err != nil {
+				return err
+			}
+			delete(parents, dirs[i])
+			numFiles++
+		}
+		return nil
+	}
+	err := filepath.Walk(d.dir, func(fp string, fi os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(d.dir, fp)
+		if err != nil {
+			return err
+		}
+		if rel == "." {
+			return nil
+		}
+		name := filepath.ToSlash(rel)
+		if matchAny(d.exclude, name) {
+			if fi.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		// directories are traversed regardless, as their content might be
+		// included explicitly
+		if len(d.include) > 0 && !matchAny(d.include, name) {
+			if fi.IsDir() {
+				hdr, err := tar.FileInfoHeader(fi, "")
+				if err != nil {
+					return err
+				}
+				hdr.Name = name + "/"
+				parents[name] = hdr
+			}
+			return nil
+		}
+		if fi.Mode()&(os.ModeSocket|os.ModeNamedPipe|os.ModeDevice) != 0 {
+			d.log.Info("skipping unsupported file", "name", name, "mode", fi.Mode())
+			return nil
+		}
+		link := ""
+		if fi.Mode()&os.ModeSymlink != 0 {
+			if link, err = os.Readlink(fp); err != nil {
+				return err
+			}
+		}
+		hdr, err := tar.FileInfoHeader(fi, link)
+		if err != nil {
+			return err
+		}
+		hdr.Name = name
+		if fi.IsDir() {
+			hdr.Name += "/"
+		}
+		if err := writeParents(name); err != nil {
+			return err
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+		numFiles++
+		if !fi.Mode().IsRegular() {
+			return nil
+		}
+		file, err := os.Open(fp)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		_, err = io.CopyN(tw, file, hdr.Size)
+		return err
+	})
+	if err != nil {
+		return numFiles, err
+	}
+	if err := tw.Close(); err != nil {
+		return numFiles, err
+	}
+	return numFiles, gw.Close()
+}
+
+// matchAny checks whether any pattern matches the name or one of its parents
+func matchAny(patterns []string, name string) bool {
+	elems := strings.Split(name, "/")
+	for _, pattern := range patterns {
+		for i := range elems {
+			subject := elems[i]
+			if strings.Contains(pattern, "/") {
+				subject = strings.Join(elems[:i+1], "/")
+			}
+			if ok, _ := path.Match(pattern, subject); ok {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,121 @@
+/*
+Copyright 2020 Backup Operator Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fs
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/kubism/backup-operator/pkg/backup/mem"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func createTestTree() string {
+	dir, err := ioutil.TempDir("", "dirsrc")
+	Expect(err).ToNot(HaveOccurred())
+	Expect(os.MkdirAll(filepath.Join(dir, "data", "cache"), 0750)).To(Succeed())
+	Expect(ioutil.WriteFile(filepath.Join(dir, "data", "db.bin"), []byte("database"), 0600)).To(Succeed())
+	Expect(ioutil.WriteFile(filepath.Join(dir, "data", "app.log"), []byte("log"), 0644)).To(Succeed())
+	Expect(ioutil.WriteFile(filepath.Join(dir, "data", "cache", "tmp"), []byte("cache"), 0644)).To(Succeed())
+	Expect(ioutil.WriteFile(filepath.Join(dir, "config.yaml"), []byte("config"), 0640)).To(Succeed())
+	Expect(os.Symlink("data/db.bin", filepath.Join(dir, "current"))).To(Succeed())
+	return dir
+}
+
+func readTestArchive(data []byte) map[string]*tar.Header {
+	gr, err := gzip.NewReader(bytes.NewReader(data))
+	Expect(err).ToNot(HaveOccurred())
+	tr := tar.NewReader(gr)
+	headers := map[string]*tar.Header{}
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		Expect(err).ToNot(HaveOccurred())
+		headers[hdr.Name] = hdr
+	}
+	return headers
+}
+
+var _ = Describe("DirSource", func() {
+	It("should archive directory", func() {
+		dir := createTestTree()
+		defer os.RemoveAll(dir)
+		src, err := NewDirSource(dir, "backup.tar.gz", nil, nil)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(src).ToNot(BeNil())
+		dst, _ := mem.NewBufferDestination()
+		written, err := src.Stream(dst)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(written).To(BeNumerically(">", 0))
+		headers := readTestArchive(dst.Data["backup.tar.gz"])
+		Expect(headers).To(HaveLen(7))
+		Expect(headers).To(HaveKey("data/cache/tmp"))
+		Expect(headers["data/"].Typeflag).To(Equal(byte(tar.TypeDir)))
+		Expect(headers["data/"].Mode & 0777).To(Equal(int64(0750)))
+		Expect(headers["data/db.bin"].Mode & 0777).To(Equal(int64(0600)))
+		Expect(headers["data/db.bin"].Size).To(Equal(int64(8)))
+		Expect(headers["data/db.bin"].Uid).To(Equal(os.Getuid()))
+		Expect(headers["data/db.bin"].Gid).To(Equal(os.Getgid()))
+		Expect(headers["current"].Typeflag).To(Equal(byte(tar.TypeSymlink)))
+		Expect(headers["current"].Linkname).To(Equal("data/db.bin"))
+	})
+	It("should respect include and exclude patterns", func() {
+		dir := createTestTree()
+		defer os.RemoveAll(dir)
+		src, err := NewDirSource(dir, "backup.tar.gz", []string{"data"}, []string{"*.log", "data/cache"})
+		Expect(err).ToNot(HaveOccurred())
+		dst, _ := mem.NewBufferDestination()
+		_, err = src.Stream(dst)
+		Expect(err).ToNot(HaveOccurred())
+		headers := readTestArchive(dst.Data["backup.tar.gz"])
+		Expect(headers).To(HaveLen(2))
+		Expect(headers).To(HaveKey("data/"))
+		Expect(headers).To(HaveKey("data/db.bin"))
+	})
+	It("should archive parent directories of included files", func() {
+		dir := createTestTree()
+		defer os.RemoveAll(dir)
+		src, err := NewDirSource(dir, "backup.tar.gz", []string{"data/cache/tmp"}, nil)
+		Expect(err).ToNot(HaveOccurred())
+		dst, _ := mem.NewBufferDestination()
+		_, err = src.Stream(dst)
+		Expect(err).ToNot(HaveOccurred())
+		headers := readTestArchive(dst.Data["backup.tar.gz"])
+		Expect(headers).To(HaveLen(3))
+		Expect(headers["data/"].Mode & 0777).To(Equal(int64(0750)))
+		Expect(headers["data/cache/"].Typeflag).To(Equal(byte(tar.TypeDir)))
+		Expect(headers).To(HaveKey("data/cache/tmp"))
+	})
+	It("should fail for invalid patterns or missing directory", func() {
+		_, err := NewDirSource("/tmp", "backup.tar.gz", []string{"["}, nil)
+		Expect(err).To(HaveOccurred())
+		src, err := NewDirSource("/nonexistent", "backup.tar.gz", nil, nil)
+		Expect(err).ToNot(HaveOccurred())
+		dst, _ := mem.NewBufferDestination()
+		_, err = src.Stream(dst)
+		Expect(err).To(HaveOccurred())
+	})
+})
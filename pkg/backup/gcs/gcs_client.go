@@ -0,0 +1,390 @@
+/*
+Copyright 2020 Backup Operator Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gcs
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/google"
+)
+
+// maxErrorBodySize limits the response body included in errors
+const maxErrorBodySize = 1024
+
+const (
+	// maxChunkRetries limits the retries of a failed chunk upload
+	maxChunkRetries = 3
+	// chunkRetryDelay is multiplied by the number of failures before retrying
+	chunkRetryDelay = 1 * time.Second
+)
+
+const scope = "https://www.googleapis.com/auth/devstorage.read_write"
+
+type client struct {
+	Endpoint      string
+	Bucket        string
+	EncryptionKey *string
+	HTTP          *http.Client
+}
+
+// object is the subset of the object resource used by the client
+type object struct {
+	Name    string    `json:"name"`
+	Size    string    `json:"size"`
+	Updated time.Time `json:"updated"`
+}
+
+// newClient returns a client of the bucket authenticated with the service
+// account key of credentials. If credentials is empty, the application
+// default credentials are used, e.g. of workload identity.
+func newClient(endpoint, credentials string, withoutAuthentication bool, bucket string, encryptionKey *string) (*client, error) {
+	if bucket == "" {
+		return nil, fmt.Errorf("bucket is required")
+	}
+	if encryptionKey != nil && len(*encryptionKey) != 32 {
+		return nil, fmt.Errorf("encryption key has to be 32 bytes long")
+	}
+	if endpoint == "" {
+		endpoint = DefaultEndpoint
+	}
+	ctx := context.Background()
+	var httpClient *http.Client
+	if withoutAuthentication {
+		httpClient = &http.Client{}
+	} else if credentials != "" {
+		creds, err := google.CredentialsFromJSON(ctx, []byte(credentials), scope)
+		if err != nil {
+			return nil, err
+		}
+		httpClient = oauth2.NewClient(ctx, creds.TokenSource)
+	} else {
+		var err error
+		if httpClient, err = google.DefaultClient(ctx, scope); err != nil {
+			return nil, err
+		}
+	}
+	return &client{
+		Endpoint:      strings.TrimSuffix(endpoint, "/"),
+		Bucket:        bucket,
+		EncryptionKey: encryptionKey,
+		HTTP:          httpClient,
+	}, nil
+}
+
+func (c *client) bucketURL() string {
+	return fmt.Sprintf("%s/storage/v1/b/%s", c.Endpoint, url.PathEscape(c.Bucket))
+}
+
+func (c *client) objectURL(name string) string {
+	return fmt.Sprintf("%s/o/%s", c.bucketURL(), url.PathEscape(name))
+}
+
+// do sends the request and returns an error, if the response does not have
+// one of the expected status codes
+func (c *client) do(req *http.Request, encrypted bool, expected ...int) (*http.Response, error) {
+	if encrypted && c.EncryptionKey != nil {
+		sum := sha256.Sum256([]byte(*c.EncryptionKey))
+		req.Header.Set("x-goog-encryption-algorithm", encryptionAlgorithm)
+		req.Header.Set("x-goog-encryption-key", base64.StdEncoding.EncodeToString([]byte(*c.EncryptionKey)))
+		req.Header.Set("x-goog-encryption-key-sha256", base64.StdEncoding.EncodeToString(sum[:]))
+	}
+	res, err := c.HTTP.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	for _, code := range expected {
+		if res.StatusCode == code {
+			return res, nil
+		}
+	}
+	defer res.Body.Close()
+	msg, _ := ioutil.ReadAll(io.LimitReader(res.Body, maxErrorBodySize))
+	return nil, &statusError{
+		Code: res.StatusCode,
+		Msg:  fmt.Sprintf("%s %s returned %s: %s", req.Method, req.URL.Path, res.Status, strings.TrimSpace(string(msg))),
+	}
+}
+
+type statusError struct {
+	Code int
+	Msg  string
+}
+
+func (e *statusError) Error() string {
+	return e.Msg
+}
+
+func isNotFound(err error) bool {
+	serr, ok := err.(*statusError)
+	return ok && serr.Code == http.StatusNotFound
+}
+
+// ensureBucket makes sure the bucket exists. If a project is provided,
+// missing buckets are created.
+func (c *client) ensureBucket(project string) error {
+	req, err := http.NewRequest(http.MethodGet, c.bucketURL(), nil)
+	if err != nil {
+		return err
+	}
+	res, err := c.do(req, false, http.StatusOK)
+	if err == nil {
+		res.Body.Close()
+		return nil
+	}
+	if !isNotFound(err) || project == "" {
+		return err
+	}
+	body, err := json.Marshal(map[string]string{"name": c.Bucket})
+	if err != nil {
+		return err
+	}
+	req, err = http.NewRequest(http.MethodPost, fmt.Sprintf("%s/storage/v1/b?project=%s", c.Endpoint, url.QueryEscape(project)), bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	res, err = c.do(req, false, http.StatusOK)
+	if err != nil {
+		return err
+	}
+	return res.Body.Close()
+}
+
+// upload stores the data using a resumable upload in chunks of the provided
+// size, so the size of the data does not have to be known in advance. The
+// upload is canceled on failure, so no partial object remains.
+func (c *client) upload(name string, data io.Reader, chunkSize int) (int64, error) {
+	body, err := json.Marshal(map[string]string{"name": name})
+	if err != nil {
+		return 0, err
+	}
+	req, err := http.NewRequest(http.MethodPost, fmt.Sprintf("%s/upload/storage/v1/b/%s/o?uploadType=resumable&name=%s",
+		c.Endpoint, url.PathEscape(c.Bucket), url.QueryEscape(name)), bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	res, err := c.do(req, true, http.StatusOK)
+	if err != nil {
+		return 0, err
+	}
+	res.Body.Close()
+	session := res.Header.Get("Location")
+	if session == "" {
+		return 0, fmt.Errorf("resumable upload of %s returned no session", name)
+	}
+	written, err := c.uploadChunks(session, data, chunkSize)
+	if err != nil {
+		c.cancel(session)
+		return written, fmt.Errorf("upload of %s failed: %v", name, err)
+	}
+	return written, nil
+}
+
+// uploadChunks reads the data in chunks and uploads them to the session
+func (c *client) uploadChunks(session string, data io.Reader, chunkSize int) (int64, error) {
+	buf := make([]byte, chunkSize)
+	var offset int64
+	for {
+		n, err := io.ReadFull(data, buf)
+		last := err == io.EOF || err == io.ErrUnexpectedEOF
+		if err != nil && !last {
+			return offset, err
+		}
+		obj, err := c.uploadChunk(session, buf[:n], offset, last)
+		if err != nil {
+			return offset, err
+		}
+		if !last {
+			offset += int64(n)
+			continue
+		}
+		return strconv.ParseInt(obj.Size, 10, 64)
+	}
+}
+
+// uploadChunk uploads the chunk starting at offset. Failed requests are
+// retried after querying the amount of data persisted by the service, which
+// may persist less than sent as well. The object is returned, if the chunk is
+// the last one.
+func (c *client) uploadChunk(session string, chunk []byte, offset int64, last bool) (*object, error) {
+	total := "*"
+	if last {
+		total = strconv.FormatInt(offset+int64(len(chunk)), 10)
+	}
+	var sent int64 // bytes of the chunk persisted by the service
+	failures := 0
+	query := false
+	for {
+		data := chunk[sent:]
+		contentRange := fmt.Sprintf("bytes */%s", total)
+		if query || len(data) == 0 {
+			data = nil
+		} else {
+			start := offset + sent
+			contentRange = fmt.Sprintf("bytes %d-%d/%s", start, start+int64(len(data))-1, total)
+		}
+		obj, persisted, err := c.putChunk(session, data, contentRange)
+		if err != nil {
+			if !isRetryable(err) || failures >= maxChunkRetries {
+				return nil, err
+			}
+			failures++
+			time.Sleep(time.Duration(failures) * chunkRetryDelay)
+			query = true
+			continue
+		}
+		if obj != nil {
+			return obj, nil
+		}
+		if persisted < offset || persisted > offset+int64(len(chunk)) {
+			return nil, fmt.Errorf("service persisted %d bytes, but expected %d to %d bytes", persisted, offset, offset+int64(len(chunk)))
+		}
+		sent, query = persisted-offset, false
+		if sent == int64(len(chunk)) && !last {
+			return nil, nil
+		}
+	}
+}
+
+// putChunk sends the data with the provided Content-Range to the session.
+// Either the object is returned, if the upload is complete, or the number of
+// bytes persisted by the service.
+func (c *client) putChunk(session string, data []byte, contentRange string) (*object, int64, error) {
+	req, err := http.NewRequest(http.MethodPut, session, bytes.NewReader(data))
+	if err != nil {
+		return nil, 0, err
+	}
+	req.Header.Set("Content-Range", contentRange)
+	res, err := c.do(req, true, http.StatusOK, http.StatusCreated, http.StatusPermanentRedirect)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusPermanentRedirect {
+		var obj object
+		if err := json.NewDecoder(res.Body).Decode(&obj); err != nil {
+			return nil, 0, err
+		}
+		return &obj, 0, nil
+	}
+	// no range is returned, if nothing was persisted yet
+	persisted := res.Header.Get("Range")
+	if persisted == "" {
+		return nil, 0, nil
+	}
+	var end int64
+	if _, err := fmt.Sscanf(persisted, "bytes=0-%d", &end); err != nil {
+		return nil, 0, fmt.Errorf("invalid range %s: %v", persisted, err)
+	}
+	return nil, end + 1, nil
+}
+
+// isRetryable returns true for network errors and status codes, which
+// indicate a temporary failure of the service
+func isRetryable(err error) bool {
+	serr, ok := err.(*statusError)
+	if !ok {
+		return true
+	}
+	return serr.Code >= 500 || serr.Code == http.StatusRequestTimeout || serr.Code == http.StatusTooManyRequests
+}
+
+// cancel aborts a resumable upload, so no partial object remains
+func (c *client) cancel(session string) {
+	req, err := http.NewRequest(http.MethodDelete, session, nil)
+	if err != nil {
+		return
+	}
+	if res, err := c.HTTP.Do(req); err == nil {
+		res.Body.Close()
+	}
+}
+
+// download returns the data of the object
+func (c *client) download(name string) (io.ReadCloser, error) {
+	req, err := http.NewRequest(http.MethodGet, c.objectURL(name)+"?alt=media", nil)
+	if err != nil {
+		return nil, err
+	}
+	res, err := c.do(req, true, http.StatusOK)
+	if err != nil {
+		return nil, err
+	}
+	return res.Body, nil
+}
+
+// list returns all objects with the provided prefix
+func (c *client) list(prefix string) ([]object, error) {
+	objects := []object{}
+	pageToken := ""
+	for {
+		query := url.Values{}
+		query.Set("prefix", prefix)
+		if pageToken != "" {
+			query.Set("pageToken", pageToken)
+		}
+		req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("%s/o?%s", c.bucketURL(), query.Encode()), nil)
+		if err != nil {
+			return nil, err
+		}
+		res, err := c.do(req, false, http.StatusOK)
+		if err != nil {
+			return nil, err
+		}
+		var page struct {
+			Items         []object `json:"items"`
+			NextPageToken string   `json:"nextPageToken"`
+		}
+		err = json.NewDecoder(res.Body).Decode(&page)
+		res.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		objects = append(objects, page.Items...)
+		if page.NextPageToken == "" {
+			return objects, nil
+		}
+		pageToken = page.NextPageToken
+	}
+}
+
+// delete removes the object
+func (c *client) delete(name string) error {
+	req, err := http.NewRequest(http.MethodDelete, c.objectURL(name), nil)
+	if err != nil {
+		return err
+	}
+	res, err := c.do(req, false, http.StatusOK, http.StatusNoContent)
+	if err != nil {
+		return err
+	}
+	return res.Body.Close()
+}
@@ -0,0 +1,124 @@
+/*
+Copyright 2020 Backup Operator Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gcs
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// flakyUploadServer implements resumable uploads, which fail after persisting
+// half of the first request of a chunk and persist only half of the second one
+type flakyUploadServer struct {
+	mu       sync.Mutex
+	data     []byte
+	puts     int
+	canceled bool
+}
+
+func (s *flakyUploadServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	switch r.Method {
+	case http.MethodPost:
+		w.Header().Set("Location", fmt.Sprintf("http://%s/session", r.Host))
+		return
+	case http.MethodDelete:
+		s.canceled = true
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	body, _ := ioutil.ReadAll(r.Body)
+	contentRange := strings.TrimPrefix(r.Header.Get("Content-Range"), "bytes ")
+	parts := strings.SplitN(contentRange, "/", 2)
+	if parts[0] != "*" {
+		var start int64
+		fmt.Sscanf(parts[0], "%d-", &start)
+		if start != int64(len(s.data)) {
+			http.Error(w, "unexpected offset", http.StatusBadRequest)
+			return
+		}
+		s.puts++
+		switch s.puts % 3 {
+		case 1:
+			s.data = append(s.data, body[:len(body)/2]...)
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+			return
+		case 2:
+			s.data = append(s.data, body[:len(body)/2]...)
+		default:
+			s.data = append(s.data, body...)
+		}
+	}
+	if parts[1] == strconv.Itoa(len(s.data)) {
+		_ = json.NewEncoder(w).Encode(object{Name: "flaky", Size: parts[1]})
+		return
+	}
+	if len(s.data) > 0 {
+		w.Header().Set("Range", fmt.Sprintf("bytes=0-%d", len(s.data)-1))
+	}
+	w.WriteHeader(http.StatusPermanentRedirect)
+}
+
+var _ = Describe("client", func() {
+	It("should resume failed and partially persisted chunks", func() {
+		server := &flakyUploadServer{}
+		ts := httptest.NewServer(server)
+		defer ts.Close()
+		c, err := newClient(ts.URL, "", true, "bucket", nil)
+		Expect(err).ToNot(HaveOccurred())
+		data := make([]byte, 3*chunkSizeMultiple+17)
+		_, err = rand.Read(data)
+		Expect(err).ToNot(HaveOccurred())
+		written, err := c.upload("flaky", bytes.NewReader(data), chunkSizeMultiple)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(written).To(Equal(int64(len(data))))
+		Expect(server.data).To(Equal(data))
+		Expect(server.canceled).To(BeFalse())
+	})
+	It("should cancel the upload on failure", func() {
+		canceled := false
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch r.Method {
+			case http.MethodPost:
+				w.Header().Set("Location", fmt.Sprintf("http://%s/session", r.Host))
+			case http.MethodDelete:
+				canceled = true
+				w.WriteHeader(http.StatusNoContent)
+			default:
+				http.Error(w, "forbidden", http.StatusForbidden)
+			}
+		}))
+		defer ts.Close()
+		c, err := newClient(ts.URL, "", true, "bucket", nil)
+		Expect(err).ToNot(HaveOccurred())
+		_, err = c.upload("forbidden", bytes.NewReader([]byte("data")), chunkSizeMultiple)
+		Expect(err).To(HaveOccurred())
+		Expect(canceled).To(BeTrue())
+	})
+})
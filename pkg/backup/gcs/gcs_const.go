@@ -0,0 +1,28 @@
+/*
+Copyright 2020 Backup Operator Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gcs
+
+// DefaultEndpoint of the JSON API of Google Cloud Storage
+const DefaultEndpoint = "https://storage.googleapis.com"
+
+// DefaultChunkSize of resumable uploads. Chunks have to be a multiple of
+// 256 KiB.
+const DefaultChunkSize = 8 * 1024 * 1024
+
+const chunkSizeMultiple = 256 * 1024
+
+const encryptionAlgorithm = "AES256"
@@ -0,0 +1,137 @@
+/*
+Copyright 2020 Backup Operator Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gcs
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/kubism/backup-operator/pkg/backup"
+	"github.com/kubism/backup-operator/pkg/logger"
+)
+
+type GCSDestinationConf struct {
+	// Endpoint of the JSON API, defaults to DefaultEndpoint
+	Endpoint string
+	// Service account key in JSON format. If empty, the application default
+	// credentials are used.
+	Credentials string
+	// Disable authentication, e.g. for emulators
+	WithoutAuthentication bool
+	// Customer-supplied encryption key of 32 bytes
+	EncryptionKey *string
+	Bucket        string
+	// Project used to create the bucket, if it does not exist
+	Project string
+	Prefix  string
+	// Size of the chunks of resumable uploads, defaults to DefaultChunkSize
+	ChunkSize int
+}
+
+func NewGCSDestination(conf *GCSDestinationConf) (*GCSDestination, error) {
+	chunkSize := conf.ChunkSize
+	if chunkSize == 0 {
+		chunkSize = DefaultChunkSize
+	}
+	if chunkSize < 0 || chunkSize%chunkSizeMultiple != 0 {
+		return nil, fmt.Errorf("chunk size has to be a positive multiple of %d", chunkSizeMultiple)
+	}
+	client, err := newClient(conf.Endpoint, conf.Credentials, conf.WithoutAuthentication, conf.Bucket, conf.EncryptionKey)
+	if err != nil {
+		return nil, err
+	}
+	if err := client.ensureBucket(conf.Project); err != nil {
+		return nil, err
+	}
+	return &GCSDestination{
+		client:    client,
+		Bucket:    conf.Bucket,
+		Prefix:    conf.Prefix,
+		ChunkSize: chunkSize,
+		log:       logger.WithName("gcsdst"),
+	}, nil
+}
+
+type GCSDestination struct {
+	client    *client
+	Bucket    string
+	Prefix    string
+	ChunkSize int
+	log       logger.Logger
+}
+
+func (g *GCSDestination) Store(obj backup.Object) (int64, error) {
+	name := path.Join(g.Prefix, obj.ID)
+	g.log.Info("upload starting", "bucket", g.Bucket, "name", name)
+	written, err := g.client.upload(name, obj.Data, g.ChunkSize)
+	if err != nil {
+		return written, err
+	}
+	g.log.Info("upload successful", "numBytes", written)
+	return written, nil
+}
+
+// EnsureRetention deletes all but the newest max objects of the prefix
+func (g *GCSDestination) EnsureRetention(max int) error {
+	objects, err := g.client.list(g.Prefix)
+	if err != nil {
+		return err
+	}
+	if len(objects) <= max {
+		return nil
+	}
+	sort.Slice(objects, func(i, j int) bool {
+		if objects[i].Updated.Equal(objects[j].Updated) {
+			return objects[i].Name > objects[j].Name // names usually contain the time
+		}
+		return objects[i].Updated.After(objects[j].Updated)
+	})
+	for _, obj := range objects[max:] {
+		if err := g.client.delete(obj.Name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ListObjectNames returns the names of all objects of the destination
+// relative to its prefix
+func (g *GCSDestination) ListObjectNames() ([]string, error) {
+	prefix := g.Prefix + "/"
+	objects, err := g.client.list(prefix)
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(objects))
+	for _, obj := range objects {
+		names = append(names, strings.TrimPrefix(obj.Name, prefix))
+	}
+	return names, nil
+}
+
+// DeleteObjects deletes the objects with the provided names relative to the
+// prefix of the destination
+func (g *GCSDestination) DeleteObjects(names []string) error {
+	for _, name := range names {
+		if err := g.client.delete(path.Join(g.Prefix, name)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+/*
+Copyright 2020 Backup Operator Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gcs
+
+import (
+	"crypto/rand"
+	"io/ioutil"
+
+	"github.com/kubism/backup-operator/pkg/backup/mem"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("GCSDestination", func() {
+	It("should upload in chunks", func() {
+		data := make([]byte, 3*chunkSizeMultiple+17)
+		_, err := rand.Read(data)
+		Expect(err).ToNot(HaveOccurred())
+		src, _ := mem.NewBufferSource("chunked", data)
+		dst := newTestDestination("bucketa", "prefix", false)
+		written, err := src.Stream(dst)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(written).To(Equal(int64(len(data))))
+		rc, err := dst.client.download("prefix/chunked")
+		Expect(err).ToNot(HaveOccurred())
+		defer rc.Close()
+		Expect(ioutil.ReadAll(rc)).To(Equal(data))
+	})
+	It("should upload empty objects", func() {
+		src, _ := mem.NewBufferSource("empty", []byte{})
+		dst := newTestDestination("bucketa", "prefix", false)
+		written, err := src.Stream(dst)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(written).To(BeZero())
+	})
+	It("should upload encrypted", func() {
+		data := []byte("temporarycontent")
+		src, _ := mem.NewBufferSource("encrypted", data)
+		dst := newTestDestination("bucketb", "prefix", true)
+		_, err := src.Stream(dst)
+		Expect(err).ToNot(HaveOccurred())
+		rc, err := dst.client.download("prefix/encrypted")
+		Expect(err).ToNot(HaveOccurred())
+		defer rc.Close()
+		Expect(ioutil.ReadAll(rc)).To(Equal(data))
+	})
+	It("should reject invalid chunk sizes", func() {
+		for _, chunkSize := range []int{1000, -chunkSizeMultiple} {
+			_, err := NewGCSDestination(&GCSDestinationConf{
+				Endpoint:              endpoint,
+				WithoutAuthentication: true,
+				Bucket:                "bucketa",
+				ChunkSize:             chunkSize,
+			})
+			Expect(err).To(HaveOccurred())
+		}
+	})
+	It("should ensure retention", func() {
+		dst := newTestDestination("bucketc", "ns/plan", false)
+		for _, id := range []string{"backup-1", "backup-2", "backup-3", "backup-4"} {
+			src, _ := mem.NewBufferSource(id, []byte(id))
+			_, err := src.Stream(dst)
+			Expect(err).ToNot(HaveOccurred())
+		}
+		Expect(dst.EnsureRetention(2)).To(Succeed())
+		Expect(dst.ListObjectNames()).To(ConsistOf("backup-3", "backup-4"))
+	})
+	It("should list and delete objects relative to the prefix", func() {
+		dst := newTestDestination("bucketd", "ns/plan", false)
+		for _, id := range []string{"backup-1", "oplog/1-2.bson.gz"} {
+			src, _ := mem.NewBufferSource(id, []byte(id))
+			_, err := src.Stream(dst)
+			Expect(err).ToNot(HaveOccurred())
+		}
+		Expect(dst.ListObjectNames()).To(ConsistOf("backup-1", "oplog/1-2.bson.gz"))
+		Expect(dst.DeleteObjects([]string{"oplog/1-2.bson.gz"})).To(Succeed())
+		Expect(dst.ListObjectNames()).To(Equal([]string{"backup-1"}))
+	})
+})
@@ -0,0 +1,75 @@
+/*
+Copyright 2020 Backup Operator Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gcs
+
+import (
+	"github.com/kubism/backup-operator/pkg/backup"
+	"github.com/kubism/backup-operator/pkg/logger"
+)
+
+type GCSSourceConf struct {
+	// Endpoint of the JSON API, defaults to DefaultEndpoint
+	Endpoint string
+	// Service account key in JSON format. If empty, the application default
+	// credentials are used.
+	Credentials string
+	// Disable authentication, e.g. for emulators
+	WithoutAuthentication bool
+	// Customer-supplied encryption key the object was stored with
+	EncryptionKey *string
+	Bucket        string
+	Key           string
+}
+
+func NewGCSSource(conf *GCSSourceConf) (*GCSSource, error) {
+	client, err := newClient(conf.Endpoint, conf.Credentials, conf.WithoutAuthentication, conf.Bucket, conf.EncryptionKey)
+	if err != nil {
+		return nil, err
+	}
+	return &GCSSource{
+		client: client,
+		Bucket: conf.Bucket,
+		Key:    conf.Key,
+		log:    logger.WithName("gcssrc"),
+	}, nil
+}
+
+type GCSSource struct {
+	client *client
+	Bucket string
+	Key    string
+	log    logger.Logger
+}
+
+func (g *GCSSource) Stream(dst backup.Destination) (int64, error) {
+	log := g.log
+	log.Info("download starting", "bucket", g.Bucket, "key", g.Key)
+	data, err := g.client.download(g.Key)
+	if err != nil {
+		return 0, err
+	}
+	defer data.Close()
+	written, err := dst.Store(backup.Object{
+		ID:   g.Key,
+		Data: data,
+	})
+	if err != nil {
+		return written, err
+	}
+	log.Info("finished download", "numBytes", written)
+	return written, nil
+}
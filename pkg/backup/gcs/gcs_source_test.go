@@ -0,0 +1,46 @@
+/*
+Copyright 2020 Backup Operator Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gcs
+
+import (
+	"github.com/kubism/backup-operator/pkg/backup/mem"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("GCSSource", func() {
+	It("should download encrypted object", func() {
+		data := []byte("temporarycontent")
+		src, _ := mem.NewBufferSource("restore", data)
+		_, err := src.Stream(newTestDestination("buckete", "prefix", true))
+		Expect(err).ToNot(HaveOccurred())
+		gcsSrc, err := NewGCSSource(&GCSSourceConf{
+			Endpoint:              endpoint,
+			WithoutAuthentication: true,
+			EncryptionKey:         &encryptionKey,
+			Bucket:                "buckete",
+			Key:                   "prefix/restore",
+		})
+		Expect(err).ToNot(HaveOccurred())
+		dst, _ := mem.NewBufferDestination()
+		written, err := gcsSrc.Stream(dst)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(written).To(Equal(int64(len(data))))
+		Expect(dst.Data["prefix/restore"]).To(Equal(data))
+	})
+})
@@ -0,0 +1,98 @@
+/*
+Copyright 2020 Backup Operator Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gcs
+
+import (
+	"crypto/rand"
+	"testing"
+
+	"github.com/kubism/backup-operator/pkg/logger"
+	"github.com/kubism/backup-operator/pkg/testutil"
+	"github.com/onsi/ginkgo/reporters"
+	"github.com/ory/dockertest/v3"
+	dc "github.com/ory/dockertest/v3/docker"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var (
+	pool          *dockertest.Pool
+	gcsResource   *dockertest.Resource
+	endpoint      string
+	encryptionKey string
+)
+
+const project = "test"
+
+func TestGCS(t *testing.T) {
+	RegisterFailHandler(Fail)
+	junitReporter := reporters.NewJUnitReporter("../../../reports/gcs-junit.xml")
+	RunSpecsWithDefaultAndCustomReporters(t, "GCS", []Reporter{junitReporter})
+}
+
+var _ = BeforeSuite(func(done Done) {
+	var err error
+	log := logger.WithName("gcssetup")
+	key := make([]byte, 32)
+	_, err = rand.Read(key)
+	Expect(err).ToNot(HaveOccurred())
+	encryptionKey = string(key)
+	By("bootstrapping fake-gcs-server")
+	pool, err = dockertest.NewPool("")
+	Expect(err).ToNot(HaveOccurred())
+	// the port is fixed, as resumable uploads are redirected to the
+	// external URL
+	endpoint = "http://localhost:4443"
+	options := &dockertest.RunOptions{
+		Repository: "fsouza/fake-gcs-server",
+		Tag:        "1.38",
+		Cmd:        []string{"-scheme", "http", "-port", "4443", "-external-url", endpoint},
+		PortBindings: map[dc.Port][]dc.PortBinding{
+			"4443/tcp": {{HostPort: "4443"}},
+		},
+	}
+	gcsResource, err = pool.RunWithOptions(options)
+	Expect(err).ToNot(HaveOccurred())
+	log.Info("check fake-gcs-server connection", "endpoint", endpoint)
+	err = testutil.WaitForGCS(pool, endpoint)
+	Expect(err).ToNot(HaveOccurred())
+	log.Info("fake-gcs-server ready")
+	close(done)
+}, 60)
+
+var _ = AfterSuite(func() {
+	By("tearing down the test environment")
+	Expect(pool.Purge(gcsResource)).To(Succeed())
+})
+
+func newTestDestination(bucket, prefix string, encrypted bool) *GCSDestination {
+	conf := &GCSDestinationConf{
+		Endpoint:              endpoint,
+		WithoutAuthentication: true,
+		Bucket:                bucket,
+		Project:               project,
+		Prefix:                prefix,
+		ChunkSize:             chunkSizeMultiple,
+	}
+	if encrypted {
+		conf.EncryptionKey = &encryptionKey
+	}
+	dst, err := NewGCSDestination(conf)
+	Expect(err).ToNot(HaveOccurred())
+	return dst
+}
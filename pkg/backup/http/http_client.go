@@ -0,0 +1,107 @@
+/*
+Copyright 2020 Backup Operator Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package http
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+)
+
+// maxErrorBodySize limits the response body included in errors
+const maxErrorBodySize = 1024
+
+type HTTPConf struct {
+	// URL of the endpoint including the scheme
+	URL string
+	// Method of the request, defaults to GET for sources and POST for
+	// destinations
+	Method string
+	// Headers added to the request
+	Headers map[string]string
+	// Body of the request, only used by sources
+	Body string
+	// Username and password used for basic authentication
+	Username string
+	Password string
+	// Token used for bearer authentication
+	BearerToken string
+	// Path of the PEM encoded CA certificate to verify the endpoint with
+	CACert string
+}
+
+type client struct {
+	Conf *HTTPConf
+	HTTP *http.Client
+}
+
+func newClient(conf *HTTPConf) (*client, error) {
+	if conf.URL == "" {
+		return nil, fmt.Errorf("url is required")
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if conf.CACert != "" {
+		pem, err := ioutil.ReadFile(conf.CACert)
+		if err != nil {
+			return nil, err
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in %s", conf.CACert)
+		}
+		transport.TLSClientConfig = &tls.Config{RootCAs: pool}
+	}
+	return &client{
+		Conf: conf,
+		HTTP: &http.Client{Transport: transport},
+	}, nil
+}
+
+// do sends a request to the configured endpoint and returns an error, if the
+// response does not indicate success.
+func (c *client) do(defaultMethod string, body io.Reader) (*http.Response, error) {
+	method := c.Conf.Method
+	if method == "" {
+		method = defaultMethod
+	}
+	req, err := http.NewRequest(method, c.Conf.URL, body)
+	if err != nil {
+		return nil, err
+	}
+	for key, value := range c.Conf.Headers {
+		req.Header.Set(key, value)
+	}
+	if c.Conf.BearerToken != "" {
+		req.Header.Set("Authorization", "Bearer "+c.Conf.BearerToken)
+	} else if c.Conf.Username != "" || c.Conf.Password != "" {
+		req.SetBasicAuth(c.Conf.Username, c.Conf.Password)
+	}
+	res, err := c.HTTP.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		defer res.Body.Close()
+		msg, _ := ioutil.ReadAll(io.LimitReader(res.Body, maxErrorBodySize))
+		return nil, fmt.Errorf("%s %s returned %s: %s", method, c.Conf.URL, res.Status, strings.TrimSpace(string(msg)))
+	}
+	return res, nil
+}
@@ -0,0 +1,58 @@
+/*
+Copyright 2020 Backup Operator Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package http
+
+import (
+	"net/http"
+
+	"github.com/kubism/backup-operator/pkg/backup"
+	"github.com/kubism/backup-operator/pkg/logger"
+)
+
+type httpDestination struct {
+	Client *client
+	log    logger.Logger
+}
+
+// NewHTTPDestination returns a destination sending stored bodies to the
+// configured endpoint, e.g. to restore exports of an API.
+func NewHTTPDestination(conf *HTTPConf) (backup.Destination, error) {
+	client, err := newClient(conf)
+	if err != nil {
+		return nil, err
+	}
+
+	return &httpDestination{
+		Client: client,
+		log:    logger.WithName("httpdst"),
+	}, nil
+}
+
+func (s *httpDestination) Store(obj backup.Object) (int64, error) {
+	log := s.log
+
+	log.Info("restore starting")
+	cr := &backup.CountingReader{Reader: obj.Data}
+	res, err := s.Client.do(http.MethodPost, cr)
+	if err != nil {
+		log.Error(err, "Failed to send body")
+		return cr.N, err
+	}
+	res.Body.Close()
+	log.Info("restore finished", "numBytes", cr.N)
+	return cr.N, nil
+}
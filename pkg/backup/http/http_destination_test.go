@@ -0,0 +1,63 @@
+/*
+Copyright 2020 Backup Operator Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package http
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/kubism/backup-operator/pkg/backup/mem"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("HTTPDestination", func() {
+	It("should send stored body", func() {
+		var received []byte
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			defer GinkgoRecover()
+			Expect(r.Method).To(Equal(http.MethodPut))
+			var err error
+			received, err = ioutil.ReadAll(r.Body)
+			Expect(err).ToNot(HaveOccurred())
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		defer server.Close()
+		data := []byte(`{"realm":"test"}`)
+		src, _ := mem.NewBufferSource("export.json", data)
+		dst, err := NewHTTPDestination(&HTTPConf{URL: server.URL, Method: http.MethodPut})
+		Expect(err).ToNot(HaveOccurred())
+		written, err := src.Stream(dst)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(written).To(Equal(int64(len(data))))
+		Expect(received).To(Equal(data))
+	})
+	It("should fail on non-2xx status code", func() {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadRequest)
+		}))
+		defer server.Close()
+		src, _ := mem.NewBufferSource("export.json", []byte("{}"))
+		dst, err := NewHTTPDestination(&HTTPConf{URL: server.URL})
+		Expect(err).ToNot(HaveOccurred())
+		_, err = src.Stream(dst)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("400"))
+	})
+})
@@ -0,0 +1,69 @@
+/*
+Copyright 2020 Backup Operator Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package http
+
+import (
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/kubism/backup-operator/pkg/backup"
+	"github.com/kubism/backup-operator/pkg/logger"
+)
+
+type httpSource struct {
+	ArchiveName string
+	Client      *client
+	log         logger.Logger
+}
+
+// NewHTTPSource returns a source storing the response body of the configured
+// request. Responses without a 2xx status code fail the backup.
+func NewHTTPSource(conf *HTTPConf, archiveName string) (backup.Source, error) {
+	client, err := newClient(conf)
+	if err != nil {
+		return nil, err
+	}
+
+	return &httpSource{
+		ArchiveName: archiveName,
+		Client:      client,
+		log:         logger.WithName("httpsrc"),
+	}, nil
+}
+
+func (s *httpSource) Stream(dst backup.Destination) (int64, error) {
+	log := s.log
+
+	var body io.Reader
+	if s.Client.Conf.Body != "" {
+		body = strings.NewReader(s.Client.Conf.Body)
+	}
+	res, err := s.Client.do(http.MethodGet, body)
+	if err != nil {
+		log.Error(err, "Request failed")
+		return 0, err
+	}
+	reader := res.Body
+	defer reader.Close()
+	return backup.StreamTo(dst, s.ArchiveName, func(w io.Writer) error {
+		log.Info("starting dump")
+		numBytes, err := io.Copy(w, reader)
+		log.Info("finished dump", "numBytes", numBytes)
+		return err
+	})
+}
@@ -0,0 +1,124 @@
+/*
+Copyright 2020 Backup Operator Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package http
+
+import (
+	"encoding/pem"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+
+	"github.com/kubism/backup-operator/pkg/backup/fs"
+	"github.com/kubism/backup-operator/pkg/backup/mem"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("HTTPSource", func() {
+	It("should store response body", func() {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			defer GinkgoRecover()
+			Expect(r.Method).To(Equal(http.MethodPost))
+			Expect(r.Header.Get("Accept")).To(Equal("application/json"))
+			username, password, ok := r.BasicAuth()
+			Expect(ok).To(BeTrue())
+			Expect(username).To(Equal("user"))
+			Expect(password).To(Equal("secret"))
+			body, err := ioutil.ReadAll(r.Body)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(body)).To(Equal(`{"export":true}`))
+			_, _ = w.Write([]byte(`{"dashboards":[]}`))
+		}))
+		defer server.Close()
+		src, err := NewHTTPSource(&HTTPConf{
+			URL:      server.URL,
+			Method:   http.MethodPost,
+			Headers:  map[string]string{"Accept": "application/json"},
+			Body:     `{"export":true}`,
+			Username: "user",
+			Password: "secret",
+		}, "export.json")
+		Expect(err).ToNot(HaveOccurred())
+		dst, _ := mem.NewBufferDestination()
+		written, err := src.Stream(dst)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(written).To(Equal(int64(17)))
+		Expect(string(dst.Data["export.json"])).To(Equal(`{"dashboards":[]}`))
+	})
+	It("should fail on non-2xx status code", func() {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte("missing permission"))
+		}))
+		defer server.Close()
+		src, err := NewHTTPSource(&HTTPConf{URL: server.URL}, "export.json")
+		Expect(err).ToNot(HaveOccurred())
+		dst, _ := mem.NewBufferDestination()
+		_, err = src.Stream(dst)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("403"))
+		Expect(err.Error()).To(ContainSubstring("missing permission"))
+		Expect(dst.Data).To(BeEmpty())
+	})
+	It("should verify server with CA", func() {
+		server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			defer GinkgoRecover()
+			Expect(r.Header.Get("Authorization")).To(Equal("Bearer token"))
+			_, _ = w.Write([]byte("tls"))
+		}))
+		defer server.Close()
+		dir, err := ioutil.TempDir("", "httpsrc")
+		Expect(err).ToNot(HaveOccurred())
+		defer os.RemoveAll(dir)
+		caCert := filepath.Join(dir, "ca.pem")
+		Expect(ioutil.WriteFile(caCert, pem.EncodeToMemory(&pem.Block{
+			Type:  "CERTIFICATE",
+			Bytes: server.Certificate().Raw,
+		}), 0600)).Should(Succeed())
+		// without CA the certificate is unknown
+		src, err := NewHTTPSource(&HTTPConf{URL: server.URL, BearerToken: "token"}, "export")
+		Expect(err).ToNot(HaveOccurred())
+		dst, _ := mem.NewBufferDestination()
+		_, err = src.Stream(dst)
+		Expect(err).To(HaveOccurred())
+		src, err = NewHTTPSource(&HTTPConf{URL: server.URL, BearerToken: "token", CACert: caCert}, "export")
+		Expect(err).ToNot(HaveOccurred())
+		_, err = src.Stream(dst)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(dst.Data["export"])).To(Equal("tls"))
+	})
+	It("should not store truncated responses", func() {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Length", "1024")
+			_, _ = w.Write([]byte(`{"dashboards":[`))
+		}))
+		defer server.Close()
+		src, err := NewHTTPSource(&HTTPConf{URL: server.URL}, "export.json")
+		Expect(err).ToNot(HaveOccurred())
+		dir, err := ioutil.TempDir("", "httpsrc")
+		Expect(err).ToNot(HaveOccurred())
+		defer os.RemoveAll(dir)
+		dst, err := fs.NewDirDestination(dir)
+		Expect(err).ToNot(HaveOccurred())
+		_, err = src.Stream(dst)
+		Expect(err).To(HaveOccurred())
+		Expect(ioutil.ReadDir(dir)).To(BeEmpty())
+	})
+})
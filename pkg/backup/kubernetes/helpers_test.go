@@ -0,0 +1,76 @@
+/*
+Copyright 2020 Backup Operator Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubernetes
+
+import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	fakedynamic "k8s.io/client-go/dynamic/fake"
+	clienttesting "k8s.io/client-go/testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var testResources = []*metav1.APIResourceList{
+	{
+		GroupVersion: "v1",
+		APIResources: []metav1.APIResource{
+			{Name: "configmaps", Namespaced: true, Kind: "ConfigMap", Verbs: []string{"get", "list", "create", "update"}},
+			{Name: "secrets", Namespaced: true, Kind: "Secret", Verbs: []string{"get", "list", "create", "update"}},
+			{Name: "services", Namespaced: true, Kind: "Service", Verbs: []string{"get", "list", "create", "update"}},
+			{Name: "services/status", Namespaced: true, Kind: "Service", Verbs: []string{"get", "update"}},
+			{Name: "events", Namespaced: true, Kind: "Event", Verbs: []string{"get", "list"}},
+			{Name: "namespaces", Namespaced: false, Kind: "Namespace", Verbs: []string{"get", "list", "create"}},
+		},
+	},
+	{
+		GroupVersion: "apps/v1",
+		APIResources: []metav1.APIResource{
+			{Name: "deployments", Namespaced: true, Kind: "Deployment", Verbs: []string{"get", "list", "create", "update"}},
+			{Name: "replicasets", Namespaced: true, Kind: "ReplicaSet", Verbs: []string{"get", "list", "create", "update"}},
+		},
+	},
+}
+
+func newTestObject(apiVersion, kind, namespace, name string, fields map[string]interface{}) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{}}
+	for k, v := range fields {
+		obj.Object[k] = v
+	}
+	obj.SetAPIVersion(apiVersion)
+	obj.SetKind(kind)
+	obj.SetNamespace(namespace)
+	obj.SetName(name)
+	return obj
+}
+
+func newTestScheme() *runtime.Scheme {
+	scheme := runtime.NewScheme()
+	// fake dynamic client requires list types to be registered
+	for _, gv := range []schema.GroupVersion{{Version: "v1"}, {Group: "apps", Version: "v1"}} {
+		scheme.AddKnownTypeWithName(gv.WithKind("List"), &unstructured.UnstructuredList{})
+	}
+	return scheme
+}
+
+func newTestClients(objects ...runtime.Object) (*fakedynamic.FakeDynamicClient, *fakediscovery.FakeDiscovery) {
+	dyn := fakedynamic.NewSimpleDynamicClient(newTestScheme(), objects...)
+	disc := &fakediscovery.FakeDiscovery{Fake: &clienttesting.Fake{Resources: testResources}}
+	return dyn, disc
+}
@@ -0,0 +1,170 @@
+/*
+Copyright 2020 Backup Operator Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubernetes
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"sort"
+	"strings"
+
+	"github.com/kubism/backup-operator/pkg/backup"
+	"github.com/kubism/backup-operator/pkg/logger"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/yaml"
+)
+
+var namespaceResource = schema.GroupVersionResource{Version: "v1", Resource: "namespaces"}
+
+type kubernetesDestination struct {
+	Dynamic dynamic.Interface
+	log     logger.Logger
+}
+
+// NewKubernetesDestination creates a destination, which applies all objects
+// of an archive created by the KubernetesSource in dependency order. Existing
+// objects are updated and missing namespaces are created.
+func NewKubernetesDestination(config *rest.Config) (backup.Destination, error) {
+	dyn, err := dynamic.NewForConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	return newKubernetesDestination(dyn), nil
+}
+
+func newKubernetesDestination(dyn dynamic.Interface) *kubernetesDestination {
+	return &kubernetesDestination{
+		Dynamic: dyn,
+		log:     logger.WithName("k8sdst"),
+	}
+}
+
+type archivedObject struct {
+	Resource schema.GroupVersionResource
+	Object   *unstructured.Unstructured
+}
+
+func (d *kubernetesDestination) Store(obj backup.Object) (int64, error) {
+	log := d.log
+	data := &backup.CountingReader{Reader: obj.Data}
+	objects, err := readArchive(data)
+	if err != nil {
+		log.Error(err, "Failed to read archive")
+		return data.N, err
+	}
+	sort.SliceStable(objects, func(i, j int) bool {
+		return restorePriority(objects[i].Resource.GroupResource()) < restorePriority(objects[j].Resource.GroupResource())
+	})
+	log.Info("restore starting", "numObjects", len(objects))
+	namespaces := map[string]bool{}
+	for _, o := range objects {
+		namespace := o.Object.GetNamespace()
+		if !namespaces[namespace] {
+			if err := d.ensureNamespace(namespace); err != nil {
+				return data.N, err
+			}
+			namespaces[namespace] = true
+		}
+		if err := d.apply(o); err != nil {
+			log.Error(err, "Failed to apply object", "resource", o.Resource.GroupResource().String(), "namespace", namespace, "name", o.Object.GetName())
+			return data.N, err
+		}
+	}
+	log.Info("restore finished", "numBytes", data.N)
+	return data.N, nil
+}
+
+func (d *kubernetesDestination) ensureNamespace(name string) error {
+	ns := &unstructured.Unstructured{}
+	ns.SetAPIVersion("v1")
+	ns.SetKind("Namespace")
+	ns.SetName(name)
+	_, err := d.Dynamic.Resource(namespaceResource).Create(ns, metav1.CreateOptions{})
+	if apierrors.IsAlreadyExists(err) {
+		return nil
+	}
+	return err
+}
+
+func (d *kubernetesDestination) apply(o archivedObject) error {
+	client := d.Dynamic.Resource(o.Resource).Namespace(o.Object.GetNamespace())
+	_, err := client.Create(o.Object, metav1.CreateOptions{})
+	if !apierrors.IsAlreadyExists(err) {
+		return err
+	}
+	existing, err := client.Get(o.Object.GetName(), metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	o.Object.SetResourceVersion(existing.GetResourceVersion())
+	_, err = client.Update(o.Object, metav1.UpdateOptions{})
+	return err
+}
+
+// readArchive decodes all objects of the archive. The resource of each
+// object is derived from its path <namespace>/<resource.group>/<name>.yaml.
+func readArchive(r io.Reader) ([]archivedObject, error) {
+	gr, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, err
+	}
+	defer gr.Close()
+	tr := tar.NewReader(gr)
+	var objects []archivedObject
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+		parts := strings.Split(hdr.Name, "/")
+		if len(parts) != 3 {
+			return nil, fmt.Errorf("unexpected path in archive: %s", hdr.Name)
+		}
+		raw, err := ioutil.ReadAll(tr)
+		if err != nil {
+			return nil, err
+		}
+		obj := &unstructured.Unstructured{}
+		if err := yaml.Unmarshal(raw, &obj.Object); err != nil {
+			return nil, fmt.Errorf("decoding %s failed: %v", hdr.Name, err)
+		}
+		gv, err := schema.ParseGroupVersion(obj.GetAPIVersion())
+		if err != nil {
+			return nil, err
+		}
+		obj.SetNamespace(parts[0])
+		objects = append(objects, archivedObject{
+			Resource: gv.WithResource(schema.ParseGroupResource(parts[1]).Resource),
+			Object:   obj,
+		})
+	}
+	return objects, nil
+}
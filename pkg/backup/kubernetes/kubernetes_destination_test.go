@@ -0,0 +1,61 @@
+/*
+Copyright 2020 Backup Operator Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubernetes
+
+import (
+	"github.com/kubism/backup-operator/pkg/backup/mem"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("KubernetesDestination", func() {
+	It("should restore objects in dependency order", func() {
+		srcDyn, disc := newTestClients(newTestSourceObjects()...)
+		src := newKubernetesSource(srcDyn, disc, &KubernetesSourceConf{
+			Namespaces: []string{"app"},
+		}, "backup.tar.gz")
+		buf, _ := mem.NewBufferDestination()
+		_, err := src.Stream(buf)
+		Expect(err).ToNot(HaveOccurred())
+		existing := newTestObject("v1", "ConfigMap", "app", "config", map[string]interface{}{
+			"data": map[string]interface{}{"key": "outdated"},
+		})
+		dstDyn, _ := newTestClients(existing)
+		dst := newKubernetesDestination(dstDyn)
+		replay, _ := mem.NewBufferSource("backup.tar.gz", buf.Data["backup.tar.gz"])
+		written, err := replay.Stream(dst)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(written).To(Equal(int64(len(buf.Data["backup.tar.gz"]))))
+		cm, err := dstDyn.Resource(schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}).Namespace("app").Get("config", metav1.GetOptions{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(cm.Object["data"]).To(Equal(map[string]interface{}{"key": "value"}))
+		_, err = dstDyn.Resource(schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"}).Namespace("app").Get("web", metav1.GetOptions{})
+		Expect(err).ToNot(HaveOccurred())
+		// namespace has to be created first and services before deployments
+		var order []string
+		for _, action := range dstDyn.Actions() {
+			if action.GetVerb() == "create" {
+				order = append(order, action.GetResource().Resource)
+			}
+		}
+		Expect(order).To(Equal([]string{"namespaces", "configmaps", "services", "deployments"}))
+	})
+})
@@ -0,0 +1,101 @@
+/*
+Copyright 2020 Backup Operator Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubernetes
+
+import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// excludedResources are never backed up as they are either transient or
+// managed by the cluster itself
+var excludedResources = []schema.GroupResource{
+	{Resource: "events"},
+	{Group: "events.k8s.io", Resource: "events"},
+	{Resource: "endpoints"},
+	{Group: "discovery.k8s.io", Resource: "endpointslices"},
+	{Group: "coordination.k8s.io", Resource: "leases"},
+	{Group: "metrics.k8s.io", Resource: "pods"},
+}
+
+// restoreOrder defines the order resources are restored in, so dependencies
+// exist before their dependents. All other resources, e.g. custom
+// resources, are restored afterwards.
+var restoreOrder = []schema.GroupResource{
+	{Resource: "serviceaccounts"},
+	{Resource: "secrets"},
+	{Resource: "configmaps"},
+	{Resource: "limitranges"},
+	{Resource: "resourcequotas"},
+	{Resource: "persistentvolumeclaims"},
+	{Group: "rbac.authorization.k8s.io", Resource: "roles"},
+	{Group: "rbac.authorization.k8s.io", Resource: "rolebindings"},
+	{Resource: "services"},
+	{Group: "networking.k8s.io", Resource: "networkpolicies"},
+	{Group: "policy", Resource: "poddisruptionbudgets"},
+	{Group: "apps", Resource: "deployments"},
+	{Group: "apps", Resource: "statefulsets"},
+	{Group: "apps", Resource: "daemonsets"},
+	{Group: "apps", Resource: "replicasets"},
+	{Group: "batch", Resource: "jobs"},
+	{Group: "batch", Resource: "cronjobs"},
+	{Resource: "pods"},
+	{Group: "autoscaling", Resource: "horizontalpodautoscalers"},
+	{Group: "networking.k8s.io", Resource: "ingresses"},
+	{Group: "extensions", Resource: "ingresses"},
+}
+
+func restorePriority(gr schema.GroupResource) int {
+	for i, r := range restoreOrder {
+		if r == gr {
+			return i
+		}
+	}
+	return len(restoreOrder)
+}
+
+// skip checks whether the object is recreated automatically and therefore
+// should not be part of the backup
+func skip(obj *unstructured.Unstructured) bool {
+	for _, owner := range obj.GetOwnerReferences() {
+		if owner.Controller != nil && *owner.Controller {
+			return true
+		}
+	}
+	if obj.GetKind() == "Secret" {
+		secretType, _, _ := unstructured.NestedString(obj.Object, "type")
+		return secretType == "kubernetes.io/service-account-token"
+	}
+	return false
+}
+
+// strip removes all fields set by the cluster at runtime, which would
+// prevent the object from being applied again
+func strip(obj *unstructured.Unstructured) {
+	unstructured.RemoveNestedField(obj.Object, "status")
+	for _, field := range []string{"managedFields", "resourceVersion", "uid", "selfLink", "creationTimestamp", "generation"} {
+		unstructured.RemoveNestedField(obj.Object, "metadata", field)
+	}
+	if obj.GetKind() == "Service" {
+		// allocated ips can not be reused, unless service is headless
+		clusterIP, _, _ := unstructured.NestedString(obj.Object, "spec", "clusterIP")
+		if clusterIP != "None" {
+			unstructured.RemoveNestedField(obj.Object, "spec", "clusterIP")
+			unstructured.RemoveNestedField(obj.Object, "spec", "clusterIPs")
+		}
+	}
+}
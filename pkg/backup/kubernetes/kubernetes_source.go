@@ -0,0 +1,189 @@
+/*
+Copyright 2020 Backup Operator Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubernetes
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/kubism/backup-operator/pkg/backup"
+	"github.com/kubism/backup-operator/pkg/logger"
+	"github.com/kubism/backup-operator/pkg/util"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/yaml"
+)
+
+type KubernetesSourceConf struct {
+	// Namespaces to backup
+	Namespaces []string
+	// Label selector objects have to match
+	LabelSelector string
+	// Resources to backup either as plural name (e.g. deployments) or
+	// qualified with the group (e.g. deployments.apps). If empty, all
+	// namespaced resources are backed up.
+	Resources []string
+}
+
+type kubernetesSource struct {
+	Conf        KubernetesSourceConf
+	ArchiveName string
+	Dynamic     dynamic.Interface
+	Discovery   discovery.DiscoveryInterface
+	log         logger.Logger
+}
+
+// NewKubernetesSource creates a source streaming a tar.gz archive with the
+// selected objects as YAML. Objects managed by controllers (e.g. pods of
+// deployments) are omitted.
+func NewKubernetesSource(config *rest.Config, conf *KubernetesSourceConf, archiveName string) (backup.Source, error) {
+	dyn, err := dynamic.NewForConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	disc, err := discovery.NewDiscoveryClientForConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	return newKubernetesSource(dyn, disc, conf, archiveName), nil
+}
+
+func newKubernetesSource(dyn dynamic.Interface, disc discovery.DiscoveryInterface, conf *KubernetesSourceConf, archiveName string) *kubernetesSource {
+	return &kubernetesSource{
+		Conf:        *conf,
+		ArchiveName: archiveName,
+		Dynamic:     dyn,
+		Discovery:   disc,
+		log:         logger.WithName("k8ssrc"),
+	}
+}
+
+func (s *kubernetesSource) Stream(dst backup.Destination) (int64, error) {
+	log := s.log
+	resources, err := s.resolveResources()
+	if err != nil {
+		log.Error(err, "Could not discover resources")
+		return 0, err
+	}
+	return backup.StreamTo(dst, s.ArchiveName, func(w io.Writer) error {
+		log.Info("starting dump")
+		numObjects, err := s.archive(w, resources)
+		if err != nil {
+			return err
+		}
+		log.Info("finished dump", "numObjects", numObjects)
+		return nil
+	})
+}
+
+// resolveResources returns the preferred version of all selected resources,
+// which can be listed
+func (s *kubernetesSource) resolveResources() ([]schema.GroupVersionResource, error) {
+	lists, err := discovery.ServerPreferredNamespacedResources(s.Discovery)
+	if err != nil {
+		// unavailable aggregated apis should not prevent a backup
+		if !discovery.IsGroupDiscoveryFailedError(err) {
+			return nil, err
+		}
+		s.log.Error(err, "Discovery of some groups failed")
+	}
+	var resources []schema.GroupVersionResource
+	for _, list := range lists {
+		gv, err := schema.ParseGroupVersion(list.GroupVersion)
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range list.APIResources {
+			gvr := gv.WithResource(r.Name)
+			if strings.Contains(r.Name, "/") || !util.ContainsString(r.Verbs, "list") || !s.selected(gvr.GroupResource()) {
+				continue
+			}
+			resources = append(resources, gvr)
+		}
+	}
+	return resources, nil
+}
+
+func (s *kubernetesSource) selected(gr schema.GroupResource) bool {
+	for _, excluded := range excludedResources {
+		if gr == excluded {
+			return false
+		}
+	}
+	if len(s.Conf.Resources) == 0 {
+		return true
+	}
+	for _, r := range s.Conf.Resources {
+		if r == gr.Resource || r == gr.String() {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *kubernetesSource) archive(w io.Writer, resources []schema.GroupVersionResource) (int, error) {
+	gw := gzip.NewWriter(w)
+	tw := tar.NewWriter(gw)
+	numObjects := 0
+	for _, namespace := range s.Conf.Namespaces {
+		for _, gvr := range resources {
+			list, err := s.Dynamic.Resource(gvr).Namespace(namespace).List(metav1.ListOptions{
+				LabelSelector: s.Conf.LabelSelector,
+			})
+			if err != nil {
+				return numObjects, fmt.Errorf("listing %s in %s failed: %v", gvr.GroupResource(), namespace, err)
+			}
+			for i := range list.Items {
+				obj := &list.Items[i]
+				if skip(obj) {
+					continue
+				}
+				strip(obj)
+				raw, err := yaml.Marshal(obj.Object)
+				if err != nil {
+					return numObjects, err
+				}
+				err = tw.WriteHeader(&tar.Header{
+					Name:    path.Join(namespace, gvr.GroupResource().String(), obj.GetName()+".yaml"),
+					Mode:    0644,
+					Size:    int64(len(raw)),
+					ModTime: time.Now(),
+				})
+				if err != nil {
+					return numObjects, err
+				}
+				if _, err := tw.Write(raw); err != nil {
+					return numObjects, err
+				}
+				numObjects++
+			}
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return numObjects, err
+	}
+	return numObjects, gw.Close()
+}
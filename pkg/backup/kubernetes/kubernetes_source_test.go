@@ -0,0 +1,102 @@
+/*
+Copyright 2020 Backup Operator Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubernetes
+
+import (
+	"bytes"
+
+	"github.com/kubism/backup-operator/pkg/backup/mem"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func newTestSourceObjects() []runtime.Object {
+	controller := true
+	cm := newTestObject("v1", "ConfigMap", "app", "config", map[string]interface{}{
+		"data": map[string]interface{}{"key": "value"},
+	})
+	cm.SetLabels(map[string]string{"app": "test"})
+	cm.SetResourceVersion("42")
+	cm.SetUID("1234")
+	other := newTestObject("v1", "ConfigMap", "other", "config", nil)
+	token := newTestObject("v1", "Secret", "app", "default-token", map[string]interface{}{
+		"type": "kubernetes.io/service-account-token",
+	})
+	token.SetLabels(map[string]string{"app": "test"})
+	svc := newTestObject("v1", "Service", "app", "web", map[string]interface{}{
+		"spec":   map[string]interface{}{"clusterIP": "10.0.0.1"},
+		"status": map[string]interface{}{"loadBalancer": map[string]interface{}{}},
+	})
+	svc.SetLabels(map[string]string{"app": "test"})
+	deploy := newTestObject("apps/v1", "Deployment", "app", "web", map[string]interface{}{
+		"spec": map[string]interface{}{"replicas": int64(1)},
+	})
+	deploy.SetLabels(map[string]string{"app": "test"})
+	rs := newTestObject("apps/v1", "ReplicaSet", "app", "web-1234", nil)
+	rs.SetLabels(map[string]string{"app": "test"})
+	rs.SetOwnerReferences([]metav1.OwnerReference{{APIVersion: "apps/v1", Kind: "Deployment", Name: "web", UID: "1", Controller: &controller}})
+	return []runtime.Object{cm, other, token, svc, deploy, rs}
+}
+
+var _ = Describe("KubernetesSource", func() {
+	It("should dump selected objects", func() {
+		dyn, disc := newTestClients(newTestSourceObjects()...)
+		src := newKubernetesSource(dyn, disc, &KubernetesSourceConf{
+			Namespaces:    []string{"app"},
+			LabelSelector: "app=test",
+		}, "backup.tar.gz")
+		dst, _ := mem.NewBufferDestination()
+		written, err := src.Stream(dst)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(written).To(BeNumerically(">", 0))
+		objects, err := readArchive(bytes.NewReader(dst.Data["backup.tar.gz"]))
+		Expect(err).ToNot(HaveOccurred())
+		names := []string{}
+		for _, o := range objects {
+			names = append(names, o.Resource.GroupResource().String()+"/"+o.Object.GetName())
+		}
+		Expect(names).To(ConsistOf("configmaps/config", "services/web", "deployments.apps/web"))
+		for _, o := range objects {
+			Expect(o.Object.GetResourceVersion()).To(BeEmpty())
+			Expect(string(o.Object.GetUID())).To(BeEmpty())
+			_, found, _ := unstructured.NestedFieldNoCopy(o.Object.Object, "status")
+			Expect(found).To(BeFalse())
+			if o.Object.GetKind() == "Service" {
+				_, found, _ = unstructured.NestedFieldNoCopy(o.Object.Object, "spec", "clusterIP")
+				Expect(found).To(BeFalse())
+			}
+		}
+	})
+	It("should only dump selected resources", func() {
+		dyn, disc := newTestClients(newTestSourceObjects()...)
+		src := newKubernetesSource(dyn, disc, &KubernetesSourceConf{
+			Namespaces: []string{"app", "other"},
+			Resources:  []string{"configmaps", "deployments.apps"},
+		}, "backup.tar.gz")
+		dst, _ := mem.NewBufferDestination()
+		_, err := src.Stream(dst)
+		Expect(err).ToNot(HaveOccurred())
+		objects, err := readArchive(bytes.NewReader(dst.Data["backup.tar.gz"]))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(objects).To(HaveLen(3))
+	})
+})
@@ -0,0 +1,93 @@
+/*
+Copyright 2020 Backup Operator Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mongodb
+
+import (
+	"bytes"
+	"time"
+
+	"github.com/kubism/backup-operator/pkg/backup"
+	"github.com/kubism/backup-operator/pkg/backup/mem"
+	"github.com/kubism/backup-operator/pkg/testutil"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("MongoDBParallelism", func() {
+	const (
+		database    = "benchmark"
+		collections = 8
+		documents   = 5000
+		parallel    = 4
+	)
+
+	BeforeEach(func() {
+		if !shouldRunLongTests {
+			Skip("TEST_LONG not set")
+		}
+		names, err := testutil.ListNamespaces(srcURI, database)
+		Expect(err).ToNot(HaveOccurred())
+		if len(names) == 0 {
+			Expect(testutil.InsertBenchmarkData(srcURI, database, collections, documents)).Should(Succeed())
+		}
+	})
+
+	// dumpAndRestore returns the durations of dumping and restoring the
+	// database with the provided parallelism
+	dumpAndRestore := func(parallel int) (time.Duration, time.Duration) {
+		dump, err := mem.NewBufferDestination()
+		Expect(err).ToNot(HaveOccurred())
+		src, err := NewMongoDBSource(&MongoDBSourceConf{
+			URI:                    srcURI,
+			NamespaceFilter:        NamespaceFilter{Database: database},
+			NumParallelCollections: parallel,
+		}, "benchmark.tgz")
+		Expect(err).ToNot(HaveOccurred())
+		start := time.Now()
+		_, err = src.Stream(dump)
+		dumpDuration := time.Since(start)
+		Expect(err).ToNot(HaveOccurred())
+		dst, err := NewMongoDBDestination(&MongoDBDestinationConf{
+			URI:                              dstURI,
+			Drop:                             true,
+			NumParallelCollections:           parallel,
+			NumInsertionWorkersPerCollection: parallel,
+		})
+		Expect(err).ToNot(HaveOccurred())
+		start = time.Now()
+		_, err = dst.Store(backup.Object{
+			ID:   "benchmark.tgz",
+			Data: bytes.NewReader(dump.Data["benchmark.tgz"]),
+		})
+		restoreDuration := time.Since(start)
+		Expect(err).ToNot(HaveOccurred())
+		result := dst.(RestoreResultProvider).RestoreResult()
+		Expect(result.Successes).To(BeNumerically("==", collections*documents))
+		return dumpDuration, restoreDuration
+	}
+
+	Measure("should dump and restore collections in parallel faster", func(b Benchmarker) {
+		serialDump, serialRestore := dumpAndRestore(1)
+		parallelDump, parallelRestore := dumpAndRestore(parallel)
+		b.RecordValueWithPrecision("serial dump", serialDump.Seconds(), "s", 3)
+		b.RecordValueWithPrecision("parallel dump", parallelDump.Seconds(), "s", 3)
+		b.RecordValueWithPrecision("serial restore", serialRestore.Seconds(), "s", 3)
+		b.RecordValueWithPrecision("parallel restore", parallelRestore.Seconds(), "s", 3)
+		Expect(parallelDump + parallelRestore).To(BeNumerically("<", serialDump+serialRestore))
+	}, 3)
+})
@@ -18,32 +18,168 @@ package mongodb
 
 import (
 	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
 
 	"github.com/kubism/backup-operator/pkg/backup"
 	"github.com/kubism/backup-operator/pkg/logger"
 	"github.com/mongodb/mongo-tools/mongorestore"
 )
 
-func NewMongoDBDestination(uri string) (backup.Destination, error) {
+type MongoDBDestinationConf struct {
+	// Fully qualifying MongoDB URI connection string
+	URI string
+	// Replay the oplog of dumps created with oplog and of oplog slices
+	OplogReplay bool
+	// Only replay oplog entries before the timestamp in the format
+	// <seconds>[:ordinal]
+	OplogLimit string
+	// Patterns of namespaces to restore or to skip, e.g. "shop.orders"
+	NSInclude []string
+	NSExclude []string
+	// Drop each collection before restoring it
+	Drop bool
+	// Rename namespaces matching the patterns of NSFrom to the respective
+	// patterns of NSTo, e.g. "prod.*" to "staging.*"
+	NSFrom []string
+	NSTo   []string
+	// Skip restoring indexes
+	NoIndexRestore bool
+	// Preserve the UUIDs of collections. Requires Drop.
+	PreserveUUID bool
+	// Write concern of the restore, e.g. "majority" or "{w: 1, j: true}"
+	WriteConcern string
+	// Number of collections to restore in parallel and of concurrent insert
+	// operations per collection. If zero, the defaults of mongorestore are
+	// used.
+	NumParallelCollections           int
+	NumInsertionWorkersPerCollection int
+	// TLS and authentication mechanism of the connection
+	SecurityConf
+}
+
+// MongoDBRestoreResult summarizes the last restore of a destination
+type MongoDBRestoreResult struct {
+	Successes int64
+	Failures  int64
+	BytesRead int64
+}
+
+// RestoreResultProvider is implemented by destinations, which report the
+// result of the last restore
+type RestoreResultProvider interface {
+	RestoreResult() MongoDBRestoreResult
+}
+
+func NewMongoDBDestination(conf *MongoDBDestinationConf) (backup.Destination, error) {
+	if conf.OplogLimit != "" && !conf.OplogReplay {
+		return nil, fmt.Errorf("oplog limit requires oplog replay")
+	}
+	if len(conf.NSFrom) != len(conf.NSTo) {
+		return nil, fmt.Errorf("every nsFrom requires a matching nsTo")
+	}
+	if conf.PreserveUUID && !conf.Drop {
+		return nil, fmt.Errorf("preserveUUID requires drop")
+	}
 	return &mongoDBDestination{
-		URI: uri,
-		log: logger.WithName("mongodst"),
+		Conf: *conf,
+		log:  logger.WithName("mongodst"),
 	}, nil
 }
 
 type mongoDBDestination struct {
-	URI     string
+	Conf    MongoDBDestinationConf
+	result  MongoDBRestoreResult
 	restore *mongorestore.MongoRestore
 	log     logger.Logger
 }
 
-func (m *mongoDBDestination) Store(obj backup.Object) (int64, error) {
-	log := m.log
+func (m *mongoDBDestination) args() []string {
+	c := &m.Conf
 	args := []string{
-		fmt.Sprintf("--uri=\"%s\"", m.URI),
-		"--archive",
+		fmt.Sprintf("--uri=\"%s\"", c.URI),
 		"--gzip",
 	}
+	if c.OplogReplay {
+		args = append(args, "--oplogReplay")
+	}
+	if c.OplogLimit != "" {
+		args = append(args, fmt.Sprintf("--oplogLimit=%s", c.OplogLimit))
+	}
+	for _, ns := range c.NSInclude {
+		args = append(args, fmt.Sprintf("--nsInclude=%s", ns))
+	}
+	for _, ns := range c.NSExclude {
+		args = append(args, fmt.Sprintf("--nsExclude=%s", ns))
+	}
+	if c.Drop {
+		args = append(args, "--drop")
+	}
+	for i := range c.NSFrom {
+		args = append(args, fmt.Sprintf("--nsFrom=%s", c.NSFrom[i]), fmt.Sprintf("--nsTo=%s", c.NSTo[i]))
+	}
+	if c.NoIndexRestore {
+		args = append(args, "--noIndexRestore")
+	}
+	if c.PreserveUUID {
+		args = append(args, "--preserveUUID")
+	}
+	if c.WriteConcern != "" {
+		args = append(args, fmt.Sprintf("--writeConcern=%s", c.WriteConcern))
+	}
+	if c.NumParallelCollections > 0 {
+		args = append(args, fmt.Sprintf("--numParallelCollections=%d", c.NumParallelCollections))
+	}
+	if c.NumInsertionWorkersPerCollection > 0 {
+		args = append(args, fmt.Sprintf("--numInsertionWorkersPerCollection=%d", c.NumInsertionWorkersPerCollection))
+	}
+	return args
+}
+
+// Store restores a dump or replays an oplog slice, if the ID of the object
+// is prefixed with OplogSlicePrefix. A point-in-time recovery is done by
+// storing a dump followed by the subsequent slices in order.
+func (m *mongoDBDestination) Store(obj backup.Object) (int64, error) {
+	log := m.log
+	m.result = MongoDBRestoreResult{}
+	securityArgs, cleanup, err := m.Conf.SecurityConf.toolArgs()
+	defer cleanup()
+	if err != nil {
+		return 0, err
+	}
+	args := append(m.args(), securityArgs...)
+	cr := &backup.CountingReader{Reader: obj.Data}
+	if strings.HasPrefix(obj.ID, OplogSlicePrefix) {
+		if !m.Conf.OplogReplay {
+			return 0, fmt.Errorf("oplog slice %s requires oplog replay", obj.ID)
+		}
+		// oplog files can only be read from disk and require an empty
+		// target directory
+		dir, err := ioutil.TempDir("", "oplog")
+		if err != nil {
+			return 0, err
+		}
+		defer os.RemoveAll(dir)
+		file, err := os.Create(filepath.Join(dir, "oplog.bson"))
+		if err != nil {
+			return 0, err
+		}
+		_, err = io.Copy(file, cr)
+		file.Close()
+		if err != nil {
+			return cr.N, err
+		}
+		target := filepath.Join(dir, "dump")
+		if err := os.Mkdir(target, 0700); err != nil {
+			return cr.N, err
+		}
+		args = append(args, fmt.Sprintf("--oplogFile=%s", file.Name()), target)
+	} else {
+		args = append(args, "--archive")
+	}
 	opts, err := mongorestore.ParseOptions(args, "custom", "custom")
 	if err != nil {
 		return 0, err
@@ -53,18 +189,28 @@ func (m *mongoDBDestination) Store(obj backup.Object) (int64, error) {
 		return 0, err
 	}
 	defer m.restore.Close()
-	m.restore.InputReader = obj.Data
+	m.restore.InputReader = cr
 	// start the restoral
+	log.Info("restore starting", "id", obj.ID)
 	result := m.restore.Restore()
+	m.result = MongoDBRestoreResult{
+		Successes: result.Successes,
+		Failures:  result.Failures,
+		BytesRead: cr.N,
+	}
 	if result.Err != nil {
-		return 0, result.Err
+		return cr.N, result.Err
 	}
 	if m.restore.ToolOptions.WriteConcern.Acknowledged() {
-		log.Info(fmt.Sprintf("%v document(s) restored successfully. %v document(s) failed to restore.", result.Successes, result.Failures))
+		log.Info("restore finished", "successes", result.Successes, "failures", result.Failures, "numBytes", cr.N)
 	} else {
-		log.Info("done")
+		log.Info("restore finished", "numBytes", cr.N)
 	}
-	return 0, nil // NOTE: written bytes not supported
+	return cr.N, nil
+}
+
+func (m *mongoDBDestination) RestoreResult() MongoDBRestoreResult {
+	return m.result
 }
 
 func (m *mongoDBDestination) Close() error {
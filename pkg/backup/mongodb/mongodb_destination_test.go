@@ -25,10 +25,10 @@ import (
 
 var _ = Describe("MongoDBSource", func() {
 	It("should dump to file", func() {
-		src, err := NewMongoDBSource(srcURI, "", "backup.tgz")
+		src, err := NewMongoDBSource(&MongoDBSourceConf{URI: srcURI}, "backup.tgz")
 		Expect(err).ToNot(HaveOccurred())
 		Expect(src).ToNot(BeNil())
-		dst, err := NewMongoDBDestination(dstURI)
+		dst, err := NewMongoDBDestination(&MongoDBDestinationConf{URI: dstURI})
 		Expect(err).ToNot(HaveOccurred())
 		Expect(dst).ToNot(BeNil())
 		_, err = src.Stream(dst)
@@ -36,4 +36,36 @@ var _ = Describe("MongoDBSource", func() {
 		err = testutil.FindTestData(dstURI)
 		Expect(err).ToNot(HaveOccurred())
 	})
+	It("should restore renamed namespaces and report the result", func() {
+		src, err := NewMongoDBSource(&MongoDBSourceConf{
+			URI:             srcURI,
+			NamespaceFilter: NamespaceFilter{Database: "testing"},
+		}, "backup.tgz")
+		Expect(err).ToNot(HaveOccurred())
+		dst, err := NewMongoDBDestination(&MongoDBDestinationConf{
+			URI:            dstURI,
+			Drop:           true,
+			NSFrom:         []string{"testing.*"},
+			NSTo:           []string{"staging.*"},
+			NoIndexRestore: true,
+			WriteConcern:   "{w: 1, j: true}",
+		})
+		Expect(err).ToNot(HaveOccurred())
+		written, err := src.Stream(dst)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(written).To(BeNumerically(">", 0))
+		result := dst.(RestoreResultProvider).RestoreResult()
+		Expect(result.Successes).To(BeNumerically(">=", 1))
+		Expect(result.Failures).To(BeNumerically("==", 0))
+		Expect(result.BytesRead).To(Equal(written))
+		Expect(testutil.ListNamespaces(dstURI, "staging")).To(ConsistOf("staging.numbers"))
+	})
+	It("should reject invalid options", func() {
+		_, err := NewMongoDBDestination(&MongoDBDestinationConf{URI: dstURI, NSFrom: []string{"testing.*"}})
+		Expect(err).To(HaveOccurred())
+		_, err = NewMongoDBDestination(&MongoDBDestinationConf{URI: dstURI, PreserveUUID: true})
+		Expect(err).To(HaveOccurred())
+		_, err = NewMongoDBDestination(&MongoDBDestinationConf{URI: dstURI, OplogLimit: "1:1"})
+		Expect(err).To(HaveOccurred())
+	})
 })
@@ -0,0 +1,156 @@
+/*
+Copyright 2020 Backup Operator Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mongodb
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// NamespaceFilter selects the namespaces to dump. Patterns may contain the
+// wildcard "*" matching any characters, e.g. "audit_*" or "tenant_*.orders".
+type NamespaceFilter struct {
+	// Database to dump. If empty, all databases are dumped.
+	Database string
+	// Patterns of collection names to include. If empty, all collections
+	// are included.
+	IncludeCollections []string
+	// Patterns of collection names to exclude
+	ExcludeCollections []string
+	// Patterns of namespaces (<database>.<collection>) to include. If empty,
+	// all namespaces are included.
+	IncludeNamespaces []string
+	// Patterns of namespaces (<database>.<collection>) to exclude
+	ExcludeNamespaces []string
+}
+
+// IsEmpty returns true if the filter selects all namespaces of the database
+func (f *NamespaceFilter) IsEmpty() bool {
+	return len(f.IncludeCollections) == 0 && len(f.ExcludeCollections) == 0 &&
+		len(f.IncludeNamespaces) == 0 && len(f.ExcludeNamespaces) == 0
+}
+
+// Matches returns true if the namespace is selected by the filter
+func (f *NamespaceFilter) Matches(db, coll string) bool {
+	if f.Database != "" && db != f.Database {
+		return false
+	}
+	ns := db + "." + coll
+	if len(f.IncludeCollections) > 0 && !matchesAny(f.IncludeCollections, coll) {
+		return false
+	}
+	if len(f.IncludeNamespaces) > 0 && !matchesAny(f.IncludeNamespaces, ns) {
+		return false
+	}
+	return !matchesAny(f.ExcludeCollections, coll) && !matchesAny(f.ExcludeNamespaces, ns)
+}
+
+// MatchesDatabase returns true if the filter may select namespaces of the
+// database
+func (f *NamespaceFilter) MatchesDatabase(db string) bool {
+	if f.Database != "" && db != f.Database {
+		return false
+	}
+	if len(f.IncludeNamespaces) == 0 {
+		return true
+	}
+	for _, pattern := range f.IncludeNamespaces {
+		parts := strings.SplitN(pattern, ".", 2)
+		if len(parts) == 1 || wildcardToRegexp(parts[0]).MatchString(db) {
+			return true
+		}
+	}
+	return false
+}
+
+// exclusions computes the database and the collections to exclude, which
+// mongodump requires to dump the selected namespaces of the provided
+// collections by database. As mongodump only excludes collections by name,
+// selections spanning multiple databases need to be consistent, e.g.
+// "orders" can not be selected in one database, but not in another one.
+func (f *NamespaceFilter) exclusions(collections map[string][]string) (string, []string, error) {
+	selected := map[string]map[string]bool{}
+	for db, colls := range collections {
+		for _, coll := range colls {
+			if f.Matches(db, coll) {
+				if selected[db] == nil {
+					selected[db] = map[string]bool{}
+				}
+				selected[db][coll] = true
+			}
+		}
+	}
+	database := f.Database
+	if database == "" && len(selected) == 1 {
+		for db := range selected {
+			database = db
+		}
+	}
+	excluded := map[string]bool{}
+	if database != "" {
+		for _, coll := range collections[database] {
+			if !selected[database][coll] {
+				excluded[coll] = true
+			}
+		}
+		return database, sortedKeys(excluded), nil
+	}
+	selectedNames := map[string]bool{}
+	for _, colls := range selected {
+		for coll := range colls {
+			selectedNames[coll] = true
+		}
+	}
+	for db, colls := range collections {
+		for _, coll := range colls {
+			if !selectedNames[coll] {
+				excluded[coll] = true
+			} else if !selected[db][coll] {
+				return "", nil, fmt.Errorf("namespace %s.%s is excluded, but collections with the same name in other databases are included, which mongodump can not dump together", db, coll)
+			}
+		}
+	}
+	return "", sortedKeys(excluded), nil
+}
+
+func matchesAny(patterns []string, s string) bool {
+	for _, pattern := range patterns {
+		if wildcardToRegexp(pattern).MatchString(s) {
+			return true
+		}
+	}
+	return false
+}
+
+func wildcardToRegexp(pattern string) *regexp.Regexp {
+	parts := strings.Split(pattern, "*")
+	for i := range parts {
+		parts[i] = regexp.QuoteMeta(parts[i])
+	}
+	return regexp.MustCompile("^" + strings.Join(parts, ".*") + "$")
+}
+
+func sortedKeys(m map[string]bool) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,85 @@
+/*
+Copyright 2020 Backup Operator Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mongodb
+
+import (
+	"github.com/kubism/backup-operator/pkg/testutil"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("NamespaceFilter", func() {
+	collections := map[string][]string{
+		"tenant_a": {"orders", "users", "audit_2020"},
+		"tenant_b": {"orders", "users", "audit_2020"},
+		"shared":   {"config"},
+	}
+	DescribeTable("should resolve exclusions",
+		func(f NamespaceFilter, database string, excluded []string) {
+			db, ex, err := f.exclusions(collections)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(db).To(Equal(database))
+			Expect(ex).To(Equal(excluded))
+		},
+		Entry("database", NamespaceFilter{Database: "tenant_a"}, "tenant_a", []string{}),
+		Entry("included collections", NamespaceFilter{Database: "tenant_a", IncludeCollections: []string{"orders", "users"}}, "tenant_a", []string{"audit_2020"}),
+		Entry("excluded collection wildcard", NamespaceFilter{Database: "tenant_b", ExcludeCollections: []string{"audit_*"}}, "tenant_b", []string{"audit_2020"}),
+		Entry("namespace of single database", NamespaceFilter{IncludeNamespaces: []string{"tenant_a.*"}, ExcludeNamespaces: []string{"*.users"}}, "tenant_a", []string{"users"}),
+		Entry("namespaces of all databases", NamespaceFilter{ExcludeNamespaces: []string{"*.audit_*"}}, "", []string{"audit_2020"}),
+		Entry("namespaces of multiple databases", NamespaceFilter{IncludeNamespaces: []string{"tenant_*.orders", "shared.*"}}, "", []string{"audit_2020", "users"}),
+	)
+	It("should reject inconsistent namespaces of multiple databases", func() {
+		f := NamespaceFilter{IncludeNamespaces: []string{"tenant_a.orders", "tenant_b.users"}}
+		_, _, err := f.exclusions(collections)
+		Expect(err).To(HaveOccurred())
+	})
+	It("should only match complete names", func() {
+		f := NamespaceFilter{IncludeCollections: []string{"order"}}
+		Expect(f.Matches("tenant_a", "orders")).To(BeFalse())
+		f = NamespaceFilter{IncludeCollections: []string{"order?"}}
+		Expect(f.Matches("tenant_a", "orders")).To(BeFalse())
+		f = NamespaceFilter{IncludeNamespaces: []string{"tenant_a.*"}}
+		Expect(f.Matches("tenant_a", "orders")).To(BeTrue())
+		Expect(f.Matches("tenant_ab", "orders")).To(BeFalse())
+	})
+})
+
+var _ = Describe("MongoDBSource", func() {
+	It("should only dump filtered namespaces", func() {
+		Expect(testutil.InsertNamespaceTestData(srcURI,
+			"filter_a.orders", "filter_a.users", "filter_a.audit_2020",
+			"filter_b.orders",
+		)).Should(Succeed())
+		src, err := NewMongoDBSource(&MongoDBSourceConf{
+			URI: srcURI,
+			NamespaceFilter: NamespaceFilter{
+				Database:           "filter_a",
+				IncludeCollections: []string{"orders", "audit_*"},
+				ExcludeCollections: []string{"audit_*"},
+			},
+		}, "filtered.tgz")
+		Expect(err).ToNot(HaveOccurred())
+		dst, err := NewMongoDBDestination(&MongoDBDestinationConf{URI: dstURI})
+		Expect(err).ToNot(HaveOccurred())
+		_, err = src.Stream(dst)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(testutil.ListNamespaces(dstURI, "filter_a")).To(ConsistOf("filter_a.orders"))
+		Expect(testutil.ListNamespaces(dstURI, "filter_b")).To(BeEmpty())
+	})
+})
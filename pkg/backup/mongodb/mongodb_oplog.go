@@ -0,0 +1,249 @@
+/*
+Copyright 2020 Backup Operator Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mongodb
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	// OplogSlicePrefix is prepended to the names of all oplog slices
+	OplogSlicePrefix = "oplog/"
+	// OplogSliceSuffix is appended to the names of all oplog slices
+	OplogSliceSuffix = ".bson.gz"
+
+	oplogDatabase   = "local"
+	oplogCollection = "oplog.rs"
+)
+
+var (
+	baseArchiveName  = regexp.MustCompile(`^backup-\d{14}-(\d{10})\.(\d{10})\.tgz$`)
+	plainArchiveName = regexp.MustCompile(`^backup-\d{14}\.tgz$`)
+	oplogSliceName   = regexp.MustCompile(`^oplog/(\d{10})\.(\d{10})-(\d{10})\.(\d{10})\.bson\.gz$`)
+)
+
+func formatTimestamp(ts primitive.Timestamp) string {
+	return fmt.Sprintf("%010d.%010d", ts.T, ts.I)
+}
+
+func parseTimestamp(t, i string) primitive.Timestamp {
+	// NOTE: only used with matches of the above expressions
+	tv, _ := strconv.ParseUint(t, 10, 32)
+	iv, _ := strconv.ParseUint(i, 10, 32)
+	return primitive.Timestamp{T: uint32(tv), I: uint32(iv)}
+}
+
+// BaseArchiveName returns the name of a dump created with oplog, which
+// includes the latest timestamp of the oplog before the dump was started.
+// Oplog slices are continued from this timestamp.
+func BaseArchiveName(now time.Time, ts primitive.Timestamp) string {
+	return fmt.Sprintf("backup-%s-%s.tgz", now.Format("20060102150405"), formatTimestamp(ts))
+}
+
+// OplogSliceName returns the name of the slice containing all oplog entries
+// after start up to and including end
+func OplogSliceName(start, end primitive.Timestamp) string {
+	return fmt.Sprintf("%s%s-%s%s", OplogSlicePrefix, formatTimestamp(start), formatTimestamp(end), OplogSliceSuffix)
+}
+
+// OplogTimestampString formats the timestamp as expected by --oplogLimit
+func OplogTimestampString(ts primitive.Timestamp) string {
+	return fmt.Sprintf("%d:%d", ts.T, ts.I)
+}
+
+type baseArchive struct {
+	Name string
+	TS   primitive.Timestamp
+}
+
+type oplogSlice struct {
+	Name  string
+	Start primitive.Timestamp
+	End   primitive.Timestamp
+}
+
+// parseObjectNames returns the base archives and oplog slices within the
+// names sorted from oldest to newest. Other names are ignored.
+func parseObjectNames(names []string) ([]baseArchive, []oplogSlice) {
+	bases := []baseArchive{}
+	slices := []oplogSlice{}
+	for _, name := range names {
+		if m := baseArchiveName.FindStringSubmatch(name); m != nil {
+			bases = append(bases, baseArchive{Name: name, TS: parseTimestamp(m[1], m[2])})
+		} else if m := oplogSliceName.FindStringSubmatch(name); m != nil {
+			slices = append(slices, oplogSlice{
+				Name:  name,
+				Start: parseTimestamp(m[1], m[2]),
+				End:   parseTimestamp(m[3], m[4]),
+			})
+		}
+	}
+	sort.Slice(bases, func(i, j int) bool {
+		return primitive.CompareTimestamp(bases[i].TS, bases[j].TS) < 0
+	})
+	sort.Slice(slices, func(i, j int) bool {
+		return primitive.CompareTimestamp(slices[i].End, slices[j].End) < 0
+	})
+	return bases, slices
+}
+
+// NextOplogSliceStart returns the timestamp the next oplog slice has to start
+// at to continue the chain of the provided objects. If no base archive
+// exists, no slice is required and false is returned.
+func NextOplogSliceStart(names []string) (primitive.Timestamp, bool) {
+	bases, slices := parseObjectNames(names)
+	if len(bases) == 0 {
+		return primitive.Timestamp{}, false
+	}
+	start := bases[len(bases)-1].TS
+	if len(slices) > 0 && primitive.CompareTimestamp(slices[len(slices)-1].End, start) > 0 {
+		start = slices[len(slices)-1].End
+	}
+	return start, true
+}
+
+// ObsoleteObjects returns the backups exceeding max and all oplog slices,
+// which are not required to replay the oplog on top of the oldest retained
+// base archive. Dumps created without oplog (e.g. before it was enabled) are
+// retained like base archives, but can not be used as base of a replay.
+func ObsoleteObjects(names []string, max int) []string {
+	bases, slices := parseObjectNames(names)
+	backups := []string{}
+	for _, base := range bases {
+		backups = append(backups, base.Name)
+	}
+	for _, name := range names {
+		if plainArchiveName.MatchString(name) {
+			backups = append(backups, name)
+		}
+	}
+	if len(backups) <= max {
+		return nil
+	}
+	// names start with the creation time, so they are sortable
+	sort.Strings(backups)
+	if max <= 0 {
+		return backups
+	}
+	obsolete := append([]string{}, backups[:len(backups)-max]...)
+	retained := map[string]bool{}
+	for _, name := range backups[len(backups)-max:] {
+		retained[name] = true
+	}
+	var oldest *baseArchive
+	for i := range bases {
+		if retained[bases[i].Name] {
+			oldest = &bases[i]
+			break
+		}
+	}
+	for _, slice := range slices {
+		if oldest == nil || primitive.CompareTimestamp(slice.End, oldest.TS) <= 0 {
+			obsolete = append(obsolete, slice.Name)
+		}
+	}
+	return obsolete
+}
+
+// SelectPointInTime returns the newest base archive started before target
+// followed by all oplog slices required to replay the oplog up to target.
+// The objects have to be restored in order with --oplogLimit set to target.
+// Targets within the runtime of a dump restore the state at its end.
+func SelectPointInTime(names []string, target primitive.Timestamp) ([]string, error) {
+	bases, slices := parseObjectNames(names)
+	var base *baseArchive
+	for i := range bases {
+		if primitive.CompareTimestamp(bases[i].TS, target) < 0 {
+			base = &bases[i]
+		}
+	}
+	if base == nil {
+		return nil, fmt.Errorf("no base backup found before %s", OplogTimestampString(target))
+	}
+	selected := []string{base.Name}
+	last := base.TS
+	for _, slice := range slices {
+		if primitive.CompareTimestamp(slice.End, base.TS) <= 0 {
+			continue
+		}
+		if primitive.CompareTimestamp(last, target) >= 0 {
+			break
+		}
+		if primitive.CompareTimestamp(slice.Start, last) > 0 {
+			return nil, fmt.Errorf("oplog chain has a gap between %s and %s", OplogTimestampString(last), OplogTimestampString(slice.Start))
+		}
+		selected = append(selected, slice.Name)
+		last = slice.End
+	}
+	if primitive.CompareTimestamp(last, target) < 0 {
+		return nil, fmt.Errorf("oplog chain ends at %s before %s", OplogTimestampString(last), OplogTimestampString(target))
+	}
+	return selected, nil
+}
+
+func connect(ctx context.Context, uri string, security SecurityConf) (*mongo.Client, error) {
+	opts, err := security.clientOptions(uri)
+	if err != nil {
+		return nil, err
+	}
+	client, err := mongo.NewClient(opts)
+	if err != nil {
+		return nil, err
+	}
+	if err := client.Connect(ctx); err != nil {
+		return nil, err
+	}
+	return client, nil
+}
+
+// oplogTimestamp returns the timestamp of the oldest or newest oplog entry
+func oplogTimestamp(ctx context.Context, client *mongo.Client, newest bool) (primitive.Timestamp, error) {
+	order := 1
+	if newest {
+		order = -1
+	}
+	var entry struct {
+		TS primitive.Timestamp `bson:"ts"`
+	}
+	err := client.Database(oplogDatabase).Collection(oplogCollection).FindOne(ctx, bson.D{},
+		options.FindOne().SetSort(bson.D{{Key: "$natural", Value: order}})).Decode(&entry)
+	if err == mongo.ErrNoDocuments {
+		return primitive.Timestamp{}, fmt.Errorf("oplog is empty, a replica set is required")
+	}
+	return entry.TS, err
+}
+
+// LatestOplogTimestamp returns the timestamp of the newest oplog entry
+func LatestOplogTimestamp(uri string, security SecurityConf) (primitive.Timestamp, error) {
+	ctx := context.Background()
+	client, err := connect(ctx, uri, security)
+	if err != nil {
+		return primitive.Timestamp{}, err
+	}
+	defer client.Disconnect(ctx)
+	return oplogTimestamp(ctx, client, true)
+}
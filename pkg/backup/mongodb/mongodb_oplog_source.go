@@ -0,0 +1,116 @@
+/*
+Copyright 2020 Backup Operator Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mongodb
+
+import (
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/kubism/backup-operator/pkg/backup"
+	"github.com/kubism/backup-operator/pkg/logger"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type MongoDBOplogSourceConf struct {
+	// Fully qualifying MongoDB URI connection string
+	URI string
+	// Timestamp of the last entry already captured, e.g. determined by
+	// NextOplogSliceStart
+	Start primitive.Timestamp
+	// TLS and authentication mechanism of the connection
+	SecurityConf
+}
+
+// NewMongoDBOplogSource returns a source storing all oplog entries after the
+// start up to the newest entry as gzipped BSON, which can be replayed by the
+// destination. Nothing is stored, if no newer entries exist.
+func NewMongoDBOplogSource(conf *MongoDBOplogSourceConf) (backup.Source, error) {
+	return &mongoDBOplogSource{
+		URI:      conf.URI,
+		Start:    conf.Start,
+		Security: conf.SecurityConf,
+		log:      logger.WithName("mongooplogsrc"),
+	}, nil
+}
+
+type mongoDBOplogSource struct {
+	URI      string
+	Start    primitive.Timestamp
+	Security SecurityConf
+	log      logger.Logger
+}
+
+func (m *mongoDBOplogSource) Stream(dst backup.Destination) (int64, error) {
+	log := m.log
+	ctx := context.Background()
+	client, err := connect(ctx, m.URI, m.Security)
+	if err != nil {
+		return 0, err
+	}
+	defer client.Disconnect(ctx)
+	// make sure no entries were lost since the last slice
+	oldest, err := oplogTimestamp(ctx, client, false)
+	if err != nil {
+		return 0, err
+	}
+	if primitive.CompareTimestamp(oldest, m.Start) > 0 {
+		return 0, fmt.Errorf("oplog starts at %s after %s, a new backup is required", OplogTimestampString(oldest), OplogTimestampString(m.Start))
+	}
+	end, err := oplogTimestamp(ctx, client, true)
+	if err != nil {
+		return 0, err
+	}
+	if primitive.CompareTimestamp(end, m.Start) <= 0 {
+		log.Info("no new oplog entries", "start", OplogTimestampString(m.Start))
+		return 0, nil
+	}
+	cursor, err := client.Database(oplogDatabase).Collection(oplogCollection).Find(ctx, bson.D{
+		{Key: "ts", Value: bson.D{{Key: "$gt", Value: m.Start}, {Key: "$lte", Value: end}}},
+	})
+	if err != nil {
+		return 0, err
+	}
+	defer cursor.Close(ctx)
+	// process output with destination implementation
+	log.Info("start storing dump")
+	return backup.StreamTo(dst, OplogSliceName(m.Start, end), func(w io.Writer) error {
+		log.Info("starting dump", "start", OplogTimestampString(m.Start), "end", OplogTimestampString(end))
+		// an incomplete slice would result in an undetectable gap of the oplog
+		if err := writeOplog(w, cursor); err != nil {
+			return err
+		}
+		log.Info("finished dump")
+		return nil
+	})
+}
+
+func writeOplog(w io.Writer, cursor *mongo.Cursor) error {
+	zw := gzip.NewWriter(w)
+	for cursor.Next(context.Background()) {
+		if _, err := zw.Write(cursor.Current); err != nil {
+			return err
+		}
+	}
+	if err := cursor.Err(); err != nil {
+		return err
+	}
+	return zw.Close()
+}
@@ -0,0 +1,160 @@
+/*
+Copyright 2020 Backup Operator Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mongodb
+
+import (
+	"bytes"
+	"fmt"
+	"time"
+
+	"github.com/kubism/backup-operator/pkg/backup"
+	"github.com/kubism/backup-operator/pkg/backup/mem"
+	"github.com/kubism/backup-operator/pkg/testutil"
+	"github.com/ory/dockertest/v3"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func objectNames(dst *mem.BufferDestination) []string {
+	names := []string{}
+	for name := range dst.Data {
+		names = append(names, name)
+	}
+	return names
+}
+
+var _ = Describe("OplogChain", func() {
+	ts := func(t uint32) primitive.Timestamp {
+		return primitive.Timestamp{T: t, I: 1}
+	}
+	now := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	names := []string{
+		BaseArchiveName(now, ts(100)),
+		OplogSliceName(ts(100), ts(150)),
+		OplogSliceName(ts(150), ts(200)),
+		BaseArchiveName(now, ts(180)),
+		OplogSliceName(ts(200), ts(250)),
+		BaseArchiveName(now, ts(240)),
+		OplogSliceName(ts(250), ts(300)),
+		"backup-20191231000000.tgz",
+	}
+
+	It("should continue after the latest slice", func() {
+		start, ok := NextOplogSliceStart(names)
+		Expect(ok).To(BeTrue())
+		Expect(start).To(Equal(ts(300)))
+		start, ok = NextOplogSliceStart(names[:1])
+		Expect(ok).To(BeTrue())
+		Expect(start).To(Equal(ts(100)))
+		_, ok = NextOplogSliceStart(names[7:])
+		Expect(ok).To(BeFalse())
+	})
+	It("should keep slices of retained backups", func() {
+		Expect(ObsoleteObjects(names, 4)).To(BeEmpty())
+		Expect(ObsoleteObjects(names, 3)).To(ConsistOf(names[7]))
+		Expect(ObsoleteObjects(names, 2)).To(ConsistOf(names[7], names[0], names[1]))
+		Expect(ObsoleteObjects(names, 1)).To(ConsistOf(names[7], names[0], names[1], names[2], names[3]))
+		// slices are not required, if only dumps without oplog are retained
+		Expect(ObsoleteObjects(append(names, "backup-20200102000000.tgz"), 1)).
+			To(ConsistOf(names[7], names[0], names[1], names[2], names[3], names[5], names[4], names[6]))
+	})
+	It("should select backup and slices up to target", func() {
+		Expect(SelectPointInTime(names, ts(160))).To(Equal([]string{names[0], names[1], names[2]}))
+		Expect(SelectPointInTime(names, ts(190))).To(Equal([]string{names[3], names[2]}))
+		Expect(SelectPointInTime(names, ts(300))).To(Equal([]string{names[5], names[4], names[6]}))
+		_, err := SelectPointInTime(names, ts(50))
+		Expect(err).To(HaveOccurred())
+		_, err = SelectPointInTime(names, ts(301))
+		Expect(err).To(HaveOccurred())
+		_, err = SelectPointInTime([]string{names[0], names[2]}, ts(160))
+		Expect(err).To(HaveOccurred())
+	})
+})
+
+var _ = Describe("MongoDBOplogSource", func() {
+	var (
+		rsResource *dockertest.Resource
+		rsURI      string
+	)
+
+	BeforeEach(func() {
+		var err error
+		rsResource, err = pool.RunWithOptions(&dockertest.RunOptions{
+			Repository: "mongo",
+			Tag:        "4.2",
+			Cmd:        []string{"--replSet", "rs0"},
+		})
+		Expect(err).ToNot(HaveOccurred())
+		rsURI = fmt.Sprintf("mongodb://localhost:%s/?connect=direct", rsResource.GetPort("27017/tcp"))
+		Expect(testutil.WaitForMongoDB(pool, rsURI)).Should(Succeed())
+		Expect(testutil.InitiateMongoDBReplicaSet(pool, rsURI, "rs0")).Should(Succeed())
+	})
+	AfterEach(func() {
+		Expect(pool.Purge(rsResource)).Should(Succeed())
+	})
+
+	It("should restore to a point in time", func() {
+		objects, err := mem.NewBufferDestination()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(testutil.InsertNamespaceTestData(rsURI, "pitr.before")).Should(Succeed())
+		start, err := LatestOplogTimestamp(rsURI, SecurityConf{})
+		Expect(err).ToNot(HaveOccurred())
+		src, err := NewMongoDBSource(&MongoDBSourceConf{URI: rsURI, Oplog: true}, BaseArchiveName(time.Now(), start))
+		Expect(err).ToNot(HaveOccurred())
+		_, err = src.Stream(objects)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(testutil.InsertNamespaceTestData(rsURI, "pitr.included")).Should(Succeed())
+		included, err := LatestOplogTimestamp(rsURI, SecurityConf{})
+		Expect(err).ToNot(HaveOccurred())
+		// the limit is exclusive, so the target has to be right after the
+		// insert of the included namespace to replay it
+		target := primitive.Timestamp{T: included.T, I: included.I + 1}
+		Expect(testutil.InsertNamespaceTestData(rsURI, "pitr.excluded")).Should(Succeed())
+		start, ok := NextOplogSliceStart(objectNames(objects))
+		Expect(ok).To(BeTrue())
+		src, err = NewMongoDBOplogSource(&MongoDBOplogSourceConf{URI: rsURI, Start: start})
+		Expect(err).ToNot(HaveOccurred())
+		written, err := src.Stream(objects)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(written).To(BeNumerically(">", 0))
+		// restore up to the creation of the excluded namespace
+		names, err := SelectPointInTime(objectNames(objects), target)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(names).To(HaveLen(2))
+		dst, err := NewMongoDBDestination(&MongoDBDestinationConf{
+			URI:         dstURI,
+			OplogReplay: true,
+			OplogLimit:  OplogTimestampString(target),
+		})
+		Expect(err).ToNot(HaveOccurred())
+		for _, name := range names {
+			_, err = dst.Store(backup.Object{ID: name, Data: bytes.NewReader(objects.Data[name])})
+			Expect(err).ToNot(HaveOccurred())
+		}
+		Expect(testutil.ListNamespaces(dstURI, "pitr")).To(ConsistOf("pitr.before", "pitr.included"))
+	})
+	It("should reject namespace filters", func() {
+		_, err := NewMongoDBSource(&MongoDBSourceConf{
+			URI:             rsURI,
+			NamespaceFilter: NamespaceFilter{Database: "pitr"},
+			Oplog:           true,
+		}, "")
+		Expect(err).To(HaveOccurred())
+	})
+})
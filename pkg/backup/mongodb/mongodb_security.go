@@ -0,0 +1,153 @@
+/*
+Copyright 2020 Backup Operator Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mongodb
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// SecurityConf configures TLS and the authentication mechanism in addition
+// to the URI
+type SecurityConf struct {
+	// TLS is enabled, if not nil
+	TLS *TLSConf
+	// Authentication mechanism, e.g. MONGODB-X509. If empty, the mechanism
+	// of the URI or the default mechanism is used.
+	AuthenticationMechanism string
+}
+
+// TLSConf references the files used to secure the connection
+type TLSConf struct {
+	// Path to the CA bundle used to verify the server. If empty, the system
+	// CAs are used.
+	CAFile string
+	// Path to the client certificate. The file may contain the key as well.
+	CertFile string
+	// Path to the key of the client certificate, if stored separately
+	KeyFile string
+	// Password of an encrypted key, only supported by mongo-tools
+	KeyPassword string
+	// Skip the verification of the server certificate and hostname
+	InsecureSkipVerify bool
+}
+
+// toolArgs returns the arguments of mongodump and mongorestore. As they
+// expect the certificate and key in a single file, a temporary file may be
+// created, which is removed by the returned function.
+func (c *SecurityConf) toolArgs() ([]string, func(), error) {
+	cleanup := func() {}
+	args := []string{}
+	if c.AuthenticationMechanism != "" {
+		args = append(args, fmt.Sprintf("--authenticationMechanism=%s", c.AuthenticationMechanism))
+	}
+	if c.TLS == nil {
+		return args, cleanup, nil
+	}
+	args = append(args, "--ssl")
+	if c.TLS.CAFile != "" {
+		args = append(args, fmt.Sprintf("--sslCAFile=%s", c.TLS.CAFile))
+	}
+	if c.TLS.CertFile != "" {
+		pemFile := c.TLS.CertFile
+		if c.TLS.KeyFile != "" {
+			file, err := combineFiles(c.TLS.CertFile, c.TLS.KeyFile)
+			if err != nil {
+				return nil, cleanup, err
+			}
+			pemFile = file
+			cleanup = func() { os.Remove(file) }
+		}
+		args = append(args, fmt.Sprintf("--sslPEMKeyFile=%s", pemFile))
+	}
+	if c.TLS.KeyPassword != "" {
+		args = append(args, fmt.Sprintf("--sslPEMKeyPassword=%s", c.TLS.KeyPassword))
+	}
+	if c.TLS.InsecureSkipVerify {
+		args = append(args, "--tlsInsecure")
+	}
+	return args, cleanup, nil
+}
+
+func combineFiles(paths ...string) (string, error) {
+	file, err := ioutil.TempFile("", "mongodb-*.pem")
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+	for _, path := range paths {
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			os.Remove(file.Name())
+			return "", err
+		}
+		if _, err := file.Write(append(data, '\n')); err != nil {
+			os.Remove(file.Name())
+			return "", err
+		}
+	}
+	return file.Name(), nil
+}
+
+// clientOptions returns the options of the driver connecting to the URI
+func (c *SecurityConf) clientOptions(uri string) (*options.ClientOptions, error) {
+	opts := options.Client().ApplyURI(uri)
+	if c.AuthenticationMechanism != "" {
+		credential := options.Credential{}
+		if opts.Auth != nil {
+			credential = *opts.Auth
+		}
+		credential.AuthMechanism = c.AuthenticationMechanism
+		opts.SetAuth(credential)
+	}
+	if c.TLS == nil {
+		return opts, nil
+	}
+	if c.TLS.KeyPassword != "" {
+		return nil, fmt.Errorf("encrypted keys are not supported")
+	}
+	config := &tls.Config{
+		InsecureSkipVerify: c.TLS.InsecureSkipVerify,
+	}
+	if c.TLS.CAFile != "" {
+		data, err := ioutil.ReadFile(c.TLS.CAFile)
+		if err != nil {
+			return nil, err
+		}
+		config.RootCAs = x509.NewCertPool()
+		if !config.RootCAs.AppendCertsFromPEM(data) {
+			return nil, fmt.Errorf("no certificates found in %s", c.TLS.CAFile)
+		}
+	}
+	if c.TLS.CertFile != "" {
+		keyFile := c.TLS.KeyFile
+		if keyFile == "" {
+			keyFile = c.TLS.CertFile
+		}
+		cert, err := tls.LoadX509KeyPair(c.TLS.CertFile, keyFile)
+		if err != nil {
+			return nil, err
+		}
+		config.Certificates = []tls.Certificate{cert}
+	}
+	return opts.SetTLSConfig(config), nil
+}
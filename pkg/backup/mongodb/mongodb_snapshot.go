@@ -0,0 +1,121 @@
+/*
+Copyright 2020 Backup Operator Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mongodb
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// SnapshotManifestSuffix is appended to the snapshot name to store its
+// manifest. The manifest is stored last, so only snapshots with a manifest
+// are complete.
+const SnapshotManifestSuffix = ".json"
+
+// SnapshotManifest lists all archives of a snapshot stored by a source with
+// SplitBy set
+type SnapshotManifest struct {
+	Snapshot string   `json:"snapshot"`
+	Objects  []string `json:"objects"`
+}
+
+// splitObjectName returns the snapshot, database and collection of an
+// archive stored by a source with SplitBy set. The collection is empty for
+// archives of databases.
+func splitObjectName(name string) (string, string, string, bool) {
+	parts := strings.SplitN(name, "/", 2)
+	if len(parts) != 2 || !strings.HasSuffix(parts[1], ".tgz") || strings.HasPrefix(name, OplogSlicePrefix) {
+		return "", "", "", false
+	}
+	// database names can not contain dots, but collection names can
+	ns := strings.SplitN(strings.TrimSuffix(parts[1], ".tgz"), ".", 2)
+	if len(ns) == 1 {
+		return parts[0], ns[0], "", true
+	}
+	return parts[0], ns[0], ns[1], true
+}
+
+// completedSnapshots returns all snapshots with a manifest
+func completedSnapshots(names []string) map[string]bool {
+	completed := map[string]bool{}
+	for _, name := range names {
+		if !strings.Contains(name, "/") && strings.HasSuffix(name, SnapshotManifestSuffix) {
+			completed[strings.TrimSuffix(name, SnapshotManifestSuffix)] = true
+		}
+	}
+	return completed
+}
+
+// SelectSnapshotObjects returns the archives of the snapshot, which contain
+// namespaces selected by the filter. Archives of databases are selected, if
+// the filter may select any of their collections, so the namespaces to
+// restore should additionally be limited by NSInclude of the destination.
+// Incomplete snapshots can not be selected.
+func SelectSnapshotObjects(names []string, snapshot string, filter NamespaceFilter) ([]string, error) {
+	if !completedSnapshots(names)[snapshot] {
+		return nil, fmt.Errorf("snapshot %s is incomplete", snapshot)
+	}
+	selected := []string{}
+	for _, name := range names {
+		s, db, coll, ok := splitObjectName(name)
+		if !ok || s != snapshot {
+			continue
+		}
+		if (coll == "" && filter.MatchesDatabase(db)) || (coll != "" && filter.Matches(db, coll)) {
+			selected = append(selected, name)
+		}
+	}
+	sort.Strings(selected)
+	return selected, nil
+}
+
+// ObsoleteSnapshots returns all archives and manifests of complete
+// snapshots, which exceed the max number of snapshots. Archives of
+// incomplete snapshots older than the retained snapshots are returned as
+// well. Snapshots are expected to be named after their creation time, so
+// they are sortable.
+func ObsoleteSnapshots(names []string, max int) []string {
+	completed := completedSnapshots(names)
+	sorted := sortedKeys(completed)
+	if len(sorted) <= max {
+		return nil
+	}
+	if max < 0 {
+		max = 0
+	}
+	obsolete := sorted[:len(sorted)-max]
+	// keep incomplete snapshots newer than the oldest retained snapshot,
+	// as they might still be in progress. Without retained snapshots, keep
+	// the ones newer than the newest snapshot.
+	isObsolete := func(s string) bool {
+		if max == 0 {
+			return s <= obsolete[len(obsolete)-1]
+		}
+		return s < sorted[len(sorted)-max]
+	}
+	objects := []string{}
+	for _, name := range names {
+		if s, _, _, ok := splitObjectName(name); ok && isObsolete(s) {
+			objects = append(objects, name)
+		}
+	}
+	for _, s := range obsolete {
+		objects = append(objects, s+SnapshotManifestSuffix)
+	}
+	return objects
+}
@@ -0,0 +1,112 @@
+/*
+Copyright 2020 Backup Operator Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mongodb
+
+import (
+	"bytes"
+
+	"github.com/kubism/backup-operator/pkg/backup"
+	"github.com/kubism/backup-operator/pkg/backup/mem"
+	"github.com/kubism/backup-operator/pkg/testutil"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Snapshot", func() {
+	names := []string{
+		"backup-0/shop.tgz",
+		"backup-1/shop.tgz",
+		"backup-1/users.tgz",
+		"backup-1.json",
+		"backup-2/shop.orders.tgz",
+		"backup-2/shop.orders.archive.tgz",
+		"backup-2/users.accounts.tgz",
+		"backup-2.json",
+		"backup-3/shop.orders.tgz",
+		"backup-0.tgz",
+		"oplog/0000000001.0000000001-0000000002.0000000001.bson.gz",
+	}
+
+	It("should select archives of namespaces", func() {
+		Expect(SelectSnapshotObjects(names, "backup-2", NamespaceFilter{IncludeNamespaces: []string{"shop.orders*"}})).
+			To(Equal([]string{"backup-2/shop.orders.archive.tgz", "backup-2/shop.orders.tgz"}))
+		Expect(SelectSnapshotObjects(names, "backup-2", NamespaceFilter{Database: "users"})).
+			To(Equal([]string{"backup-2/users.accounts.tgz"}))
+		Expect(SelectSnapshotObjects(names, "backup-1", NamespaceFilter{IncludeNamespaces: []string{"shop.orders"}})).
+			To(Equal([]string{"backup-1/shop.tgz"}))
+		Expect(SelectSnapshotObjects(names, "backup-1", NamespaceFilter{})).
+			To(Equal([]string{"backup-1/shop.tgz", "backup-1/users.tgz"}))
+	})
+	It("should not select archives of incomplete snapshots", func() {
+		_, err := SelectSnapshotObjects(names, "backup-3", NamespaceFilter{})
+		Expect(err).To(HaveOccurred())
+	})
+	DescribeTable("should retain complete snapshots",
+		func(max int, expected []string) {
+			Expect(ObsoleteSnapshots(names, max)).To(ConsistOf(expected))
+		},
+		Entry("more than available", 3, []string{}),
+		Entry("all available", 2, []string{}),
+		Entry("one", 1, names[0:4]),
+		Entry("none", 0, names[0:8]),
+		Entry("negative", -1, names[0:8]),
+	)
+})
+
+var _ = Describe("MongoDBSource", func() {
+	It("should dump collections into separate archives", func() {
+		Expect(testutil.InsertNamespaceTestData(srcURI, "split.orders", "split.users")).Should(Succeed())
+		src, err := NewMongoDBSource(&MongoDBSourceConf{
+			URI:             srcURI,
+			NamespaceFilter: NamespaceFilter{Database: "split"},
+			SplitBy:         SplitByCollection,
+		}, "snapshot.tgz")
+		Expect(err).ToNot(HaveOccurred())
+		objects, err := mem.NewBufferDestination()
+		Expect(err).ToNot(HaveOccurred())
+		_, err = src.Stream(objects)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(objectNames(objects)).To(ConsistOf("snapshot/split.orders.tgz", "snapshot/split.users.tgz", "snapshot.json"))
+		// restore a single collection
+		selected, err := SelectSnapshotObjects(objectNames(objects), "snapshot", NamespaceFilter{IncludeNamespaces: []string{"split.orders"}})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(selected).To(Equal([]string{"snapshot/split.orders.tgz"}))
+		dst, err := NewMongoDBDestination(&MongoDBDestinationConf{URI: dstURI})
+		Expect(err).ToNot(HaveOccurred())
+		for _, name := range selected {
+			_, err = dst.Store(backup.Object{ID: name, Data: bytes.NewReader(objects.Data[name])})
+			Expect(err).ToNot(HaveOccurred())
+		}
+		Expect(testutil.ListNamespaces(dstURI, "split")).To(ConsistOf("split.orders"))
+	})
+	It("should dump databases into separate archives", func() {
+		Expect(testutil.InsertNamespaceTestData(srcURI, "split_a.orders", "split_b.orders")).Should(Succeed())
+		src, err := NewMongoDBSource(&MongoDBSourceConf{
+			URI:             srcURI,
+			NamespaceFilter: NamespaceFilter{IncludeNamespaces: []string{"split_*.orders"}},
+			SplitBy:         SplitByDatabase,
+		}, "snapshot.tgz")
+		Expect(err).ToNot(HaveOccurred())
+		objects, err := mem.NewBufferDestination()
+		Expect(err).ToNot(HaveOccurred())
+		_, err = src.Stream(objects)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(objectNames(objects)).To(ConsistOf("snapshot/split_a.tgz", "snapshot/split_b.tgz", "snapshot.json"))
+	})
+})
@@ -17,40 +17,187 @@ limitations under the License.
 package mongodb
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
 	"fmt"
-	"github.com/mongodb/mongo-tools/common/options"
 	"io"
+	"path"
 	"regexp"
-	"time"
+	"sort"
+	"strings"
 
 	"github.com/kubism/backup-operator/pkg/backup"
 	"github.com/kubism/backup-operator/pkg/logger"
+	"github.com/mongodb/mongo-tools/common/options"
 	"github.com/mongodb/mongo-tools/mongodump"
+	"go.mongodb.org/mongo-driver/bson"
+	mongooptions "go.mongodb.org/mongo-driver/mongo/options"
 )
 
 var (
 	filter = regexp.MustCompile("[^a-zA-Z0-9]+")
 )
 
-func NewMongoDBSource(uri, database, archiveName string) (backup.Source, error) {
+type MongoDBSourceConf struct {
+	// Fully qualifying MongoDB URI connection string
+	URI string
+	// Filter of the namespaces to dump. The database of the filter is used
+	// as the database of mongodump.
+	NamespaceFilter
+	// Capture the oplog during the dump to create a consistent snapshot,
+	// which can be used as base of a point-in-time recovery
+	Oplog bool
+	// Read preference mode of mongodump, e.g. secondary. If empty, the read
+	// preference of the URI is used.
+	ReadPreference string
+	// Tag sets of the read preference in order of preference
+	ReadPreferenceTags []map[string]string
+	// Refuse to dump, if the read preference selects the primary
+	RequireSecondary bool
+	// Number of collections to dump in parallel. If zero, the default of
+	// mongodump is used.
+	NumParallelCollections int
+	// Store a separate archive per database or collection, see SplitBy
+	// constants. The archives are grouped in a directory named after the
+	// archive name without extension.
+	SplitBy string
+	// TLS and authentication mechanism of the connection
+	SecurityConf
+}
+
+const (
+	SplitByDatabase   = "database"
+	SplitByCollection = "collection"
+)
+
+// MemberProvider is implemented by sources, which report the member of the
+// replica set read by the last dump
+type MemberProvider interface {
+	Member() string
+}
+
+func NewMongoDBSource(conf *MongoDBSourceConf, archiveName string) (backup.Source, error) {
+	if conf.Oplog && !conf.NamespaceFilter.IsEmpty() {
+		return nil, fmt.Errorf("oplog is only supported for full dumps without namespace filter")
+	}
+	if conf.SplitBy != "" && conf.SplitBy != SplitByDatabase && conf.SplitBy != SplitByCollection {
+		return nil, fmt.Errorf("invalid split: %s", conf.SplitBy)
+	}
+	if conf.Oplog && conf.SplitBy != "" {
+		return nil, fmt.Errorf("oplog is only supported for full dumps without split")
+	}
+	readPreference, err := readPreferenceOption(conf.ReadPreference, conf.ReadPreferenceTags)
+	if err != nil {
+		return nil, err
+	}
 	return &mongoDBSource{
-		URI:         uri,
-		Database:    database,
-		ArchiveName: archiveName,
-		log:         logger.WithName("mongosrc"),
+		URI:              conf.URI,
+		Filter:           conf.NamespaceFilter,
+		Oplog:            conf.Oplog,
+		ReadPreference:   readPreference,
+		RequireSecondary: conf.RequireSecondary,
+		NumParallel:      conf.NumParallelCollections,
+		SplitBy:          conf.SplitBy,
+		Security:         conf.SecurityConf,
+		ArchiveName:      archiveName,
+		log:              logger.WithName("mongosrc"),
 	}, nil
 }
 
+// readPreferenceOption returns the read preference in the format expected
+// by the --readPreference option of mongodump
+func readPreferenceOption(mode string, tags []map[string]string) (string, error) {
+	if len(tags) == 0 {
+		return mode, nil
+	}
+	if mode == "" {
+		return "", fmt.Errorf("read preference tags require a read preference mode")
+	}
+	raw, err := json.Marshal(struct {
+		Mode    string              `json:"mode"`
+		TagSets []map[string]string `json:"tagSets"`
+	}{mode, tags})
+	return string(raw), err
+}
+
 type mongoDBSource struct {
-	URI         string
-	Database    string // TODO: implement
-	ArchiveName string
-	dump        *mongodump.MongoDump
-	log         logger.Logger
+	URI              string
+	Filter           NamespaceFilter
+	Oplog            bool
+	ReadPreference   string
+	RequireSecondary bool
+	NumParallel      int
+	SplitBy          string
+	Security         SecurityConf
+	ArchiveName      string
+	securityArgs     []string
+	member           string
+	dump             *mongodump.MongoDump
+	log              logger.Logger
 }
 
 func (m *mongoDBSource) Stream(dst backup.Destination) (int64, error) {
-	log := m.log
+	securityArgs, cleanup, err := m.Security.toolArgs()
+	defer cleanup()
+	if err != nil {
+		return 0, err
+	}
+	m.securityArgs = securityArgs
+	m.dump, err = m.newDump(m.Filter.Database, "", nil)
+	if err != nil {
+		return 0, err
+	}
+	if err = m.selectMember(); err != nil {
+		return 0, err
+	}
+	if m.ArchiveName == "" {
+		m.ArchiveName = filter.ReplaceAllString(m.URI+m.Filter.Database, "") + ".tgz"
+	}
+	if m.SplitBy == "" {
+		if err = m.applyFilter(); err != nil {
+			return 0, err
+		}
+		return m.streamArchive(dst, m.ArchiveName)
+	}
+	groups, err := m.splitNamespaces()
+	if err != nil {
+		return 0, err
+	}
+	m.dump.SessionProvider.Close() // only used to list the collections
+	// every group is dumped into its own archive within the snapshot
+	snapshot := strings.TrimSuffix(m.ArchiveName, ".tgz")
+	manifest := SnapshotManifest{Snapshot: snapshot, Objects: []string{}}
+	var written int64
+	for _, group := range groups {
+		m.dump, err = m.newDump(group.Database, group.Collection, group.Excluded)
+		if err != nil {
+			return written, err
+		}
+		id := path.Join(snapshot, group.objectName())
+		n, err := m.streamArchive(dst, id)
+		written += n
+		if err != nil {
+			return written, err
+		}
+		manifest.Objects = append(manifest.Objects, id)
+	}
+	// the manifest is stored last to mark the snapshot as complete
+	raw, err := json.Marshal(&manifest)
+	if err != nil {
+		return written, err
+	}
+	n, err := dst.Store(backup.Object{
+		ID:   snapshot + SnapshotManifestSuffix,
+		Data: bytes.NewReader(raw),
+	})
+	return written + n, err
+}
+
+// newDump returns an initialized dump of the database and collection
+// excluding the provided collections. If the database is empty, all
+// databases are dumped.
+func (m *mongoDBSource) newDump(database, collection string, excluded []string) (*mongodump.MongoDump, error) {
 	opts := options.New("mongodump",
 		"custom",
 		"custom",
@@ -58,7 +205,7 @@ func (m *mongoDBSource) Stream(dst backup.Destination) (int64, error) {
 		true,
 		options.EnabledOptions{Auth: true, Connection: true, Namespace: true, URI: true},
 	)
-	inputOpts := &mongodump.InputOptions{}
+	inputOpts := &mongodump.InputOptions{ReadPreference: m.ReadPreference}
 	opts.AddOptions(inputOpts)
 	outputOpts := &mongodump.OutputOptions{}
 	opts.AddOptions(outputOpts)
@@ -67,49 +214,181 @@ func (m *mongoDBSource) Stream(dst backup.Destination) (int64, error) {
 		"--archive",
 		"--gzip",
 	}
+	if m.NumParallel > 0 {
+		args = append(args, fmt.Sprintf("--numParallelCollections=%d", m.NumParallel))
+	}
+	args = append(args, m.securityArgs...)
 	_, err := opts.ParseArgs(args)
 	if err != nil {
-		return 0, err
+		return nil, err
 	}
 	// verify uri options and log them
 	opts.URI.LogUnsupportedOptions()
-	// setup dump and make sure output is piped
-	m.dump = &mongodump.MongoDump{
+	opts.Namespace.DB = database
+	opts.Namespace.Collection = collection
+	outputOpts.ExcludedCollections = excluded
+	outputOpts.Oplog = m.Oplog
+	dump := &mongodump.MongoDump{
 		ToolOptions:   opts,
 		OutputOptions: outputOpts,
 		InputOptions:  inputOpts,
 	}
-	if err = m.dump.Init(); err != nil {
-		return 0, err
+	if err = dump.Init(); err != nil {
+		return nil, err
 	}
-	pr, pw := io.Pipe()
-	m.dump.OutputWriter = pw
-	// start the backup in a separate routine
-	errc := make(chan error, 1)
-	defer close(errc)
-	go func() {
-		defer pw.Close()
+	return dump, nil
+}
+
+// streamArchive stores the archive of the current dump with the provided id
+func (m *mongoDBSource) streamArchive(dst backup.Destination, id string) (int64, error) {
+	log := m.log.WithValues("id", id)
+	log.Info("start storing dump")
+	return backup.StreamTo(dst, id, func(w io.Writer) error {
 		log.Info("starting dump")
-		if err = m.dump.Dump(); err != nil {
-			errc <- err
+		m.dump.OutputWriter = w
+		if err := m.dump.Dump(); err != nil {
+			return err
 		}
 		log.Info("finished dump")
-	}()
-	// process output with destination implementation
-	log.Info("start storing dump")
-	if m.ArchiveName == "" {
-		m.ArchiveName = filter.ReplaceAllString(m.URI+m.Database, "") + ".tgz"
-	}
-	written, dsterr := dst.Store(backup.Object{
-		ID:   m.ArchiveName,
-		Data: pr,
+		return nil
 	})
-	select {
-	case srcerr := <-errc: // return src error if possible as well
-		return written, fmt.Errorf("dst error: %v; src error: %v", dsterr, srcerr)
-	case <-time.After(1 * time.Second):
-		return written, dsterr
+}
+
+// selectMember determines the member selected by the read preference and
+// makes sure it is not the primary, if required. As mongodump selects the
+// member for every operation, the same member is only expected to be read,
+// if the topology does not change during the dump.
+func (m *mongoDBSource) selectMember() error {
+	client, err := m.dump.SessionProvider.GetSession()
+	if err != nil {
+		return err
+	}
+	var res struct {
+		IsMaster  bool   `bson:"ismaster"`
+		Secondary bool   `bson:"secondary"`
+		Me        string `bson:"me"`
+	}
+	err = client.Database("admin").RunCommand(context.Background(),
+		bson.D{{Key: "isMaster", Value: 1}},
+		mongooptions.RunCmd().SetReadPreference(m.dump.ToolOptions.ReadPreference)).Decode(&res)
+	if err != nil {
+		return err
+	}
+	m.member = res.Me
+	if m.member == "" { // standalone servers do not report their address
+		m.member = "standalone"
+	}
+	m.log.Info("selected member", "member", m.member, "primary", res.IsMaster, "secondary", res.Secondary)
+	if m.RequireSecondary && !res.Secondary {
+		return fmt.Errorf("refusing to dump from %s, as it is not a secondary", m.member)
+	}
+	return nil
+}
+
+func (m *mongoDBSource) Member() string {
+	return m.member
+}
+
+// listCollections returns the collections of the database of the filter
+// or of all databases, if no database is set
+func (m *mongoDBSource) listCollections() (map[string][]string, error) {
+	sp := m.dump.SessionProvider
+	dbs := []string{m.Filter.Database}
+	if m.Filter.Database == "" {
+		var err error
+		if dbs, err = sp.DatabaseNames(); err != nil {
+			return nil, err
+		}
+	}
+	collections := map[string][]string{}
+	for _, db := range dbs {
+		if db == "local" { // local can only be explicitly dumped
+			continue
+		}
+		names, err := sp.DB(db).ListCollectionNames(context.Background(), bson.D{})
+		if err != nil {
+			return nil, err
+		}
+		for _, name := range names {
+			// system collections are handled by mongodump itself
+			if !strings.HasPrefix(name, "system.") {
+				collections[db] = append(collections[db], name)
+			}
+		}
+	}
+	return collections, nil
+}
+
+// applyFilter resolves the namespace filter into the database and excluded
+// collections of mongodump
+func (m *mongoDBSource) applyFilter() error {
+	if m.Filter.IsEmpty() {
+		return nil
+	}
+	collections, err := m.listCollections()
+	if err != nil {
+		return err
+	}
+	database, excluded, err := m.Filter.exclusions(collections)
+	if err != nil {
+		return err
+	}
+	m.log.Info("resolved namespace filter", "database", database, "excludedCollections", excluded)
+	// NOTE: options are validated by Init already, which would reject
+	// excluded collections without a database, but mongodump applies them
+	// to every database regardless
+	m.dump.ToolOptions.Namespace.DB = database
+	m.dump.OutputOptions.ExcludedCollections = excluded
+	return nil
+}
+
+// namespaceGroup is dumped into a separate archive
+type namespaceGroup struct {
+	Database   string
+	Collection string
+	Excluded   []string
+}
+
+func (g namespaceGroup) objectName() string {
+	if g.Collection != "" {
+		return fmt.Sprintf("%s.%s.tgz", g.Database, g.Collection)
+	}
+	return g.Database + ".tgz"
+}
+
+// splitNamespaces resolves the namespace filter into groups per database
+// or per collection
+func (m *mongoDBSource) splitNamespaces() ([]namespaceGroup, error) {
+	collections, err := m.listCollections()
+	if err != nil {
+		return nil, err
+	}
+	dbs := make([]string, 0, len(collections))
+	for db := range collections {
+		dbs = append(dbs, db)
+	}
+	sort.Strings(dbs)
+	groups := []namespaceGroup{}
+	for _, db := range dbs {
+		group := namespaceGroup{Database: db, Excluded: []string{}}
+		matched := false
+		names := append([]string{}, collections[db]...)
+		sort.Strings(names)
+		for _, name := range names {
+			if !m.Filter.Matches(db, name) {
+				group.Excluded = append(group.Excluded, name)
+			} else if m.SplitBy == SplitByCollection {
+				groups = append(groups, namespaceGroup{Database: db, Collection: name})
+			} else {
+				matched = true
+			}
+		}
+		if matched {
+			groups = append(groups, group)
+		}
 	}
+	m.log.Info("resolved namespace groups", "splitBy", m.SplitBy, "count", len(groups))
+	return groups, nil
 }
 
 func (m *mongoDBSource) Close() error {
@@ -22,6 +22,7 @@ import (
 	"path/filepath"
 
 	"github.com/kubism/backup-operator/pkg/backup/fs"
+	"github.com/kubism/backup-operator/pkg/backup/mem"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -29,7 +30,7 @@ import (
 
 var _ = Describe("MongoDBSource", func() {
 	It("should dump to file", func() {
-		src, err := NewMongoDBSource(srcURI, "", "dump.tgz")
+		src, err := NewMongoDBSource(&MongoDBSourceConf{URI: srcURI}, "dump.tgz")
 		Expect(err).ToNot(HaveOccurred())
 		Expect(src).ToNot(BeNil())
 		dir, err := ioutil.TempDir("", "mongosrc")
@@ -46,4 +47,36 @@ var _ = Describe("MongoDBSource", func() {
 		Expect(err).ToNot(HaveOccurred())
 		Expect(fi.Size()).Should(BeNumerically(">", 0))
 	})
+	It("should report the member read", func() {
+		src, err := NewMongoDBSource(&MongoDBSourceConf{URI: srcURI, ReadPreference: "secondaryPreferred"}, "dump.tgz")
+		Expect(err).ToNot(HaveOccurred())
+		dst, err := mem.NewBufferDestination()
+		Expect(err).ToNot(HaveOccurred())
+		_, err = src.Stream(dst)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(src.(MemberProvider).Member()).To(Equal("standalone"))
+	})
+	It("should refuse to dump from the primary", func() {
+		src, err := NewMongoDBSource(&MongoDBSourceConf{
+			URI:              srcURI,
+			ReadPreference:   "secondaryPreferred",
+			RequireSecondary: true,
+		}, "dump.tgz")
+		Expect(err).ToNot(HaveOccurred())
+		dst, err := mem.NewBufferDestination()
+		Expect(err).ToNot(HaveOccurred())
+		_, err = src.Stream(dst)
+		Expect(err).To(HaveOccurred())
+		Expect(dst.Data).To(BeEmpty())
+	})
+	It("should pass tag sets as json", func() {
+		opt, err := readPreferenceOption("secondary", []map[string]string{{"use": "backup"}, {}})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(opt).To(Equal(`{"mode":"secondary","tagSets":[{"use":"backup"},{}]}`))
+		opt, err = readPreferenceOption("nearest", nil)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(opt).To(Equal("nearest"))
+		_, err = readPreferenceOption("", []map[string]string{{"use": "backup"}})
+		Expect(err).To(HaveOccurred())
+	})
 })
@@ -18,6 +18,7 @@ package mongodb
 
 import (
 	"fmt"
+	"os"
 	"testing"
 
 	"github.com/kubism/backup-operator/pkg/logger"
@@ -35,6 +36,8 @@ var (
 	dstResource *dockertest.Resource
 	srcURI      string
 	dstURI      string
+
+	shouldRunLongTests bool = os.Getenv("TEST_LONG") != ""
 )
 
 func TestMongoDB(t *testing.T) {
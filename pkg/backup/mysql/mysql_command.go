@@ -0,0 +1,54 @@
+/*
+Copyright 2020 Backup Operator Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mysql
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"os/exec"
+)
+
+// Binaries of the MySQL client tools, which are expected in PATH
+var (
+	MySQLDumpBin = "mysqldump"
+	MySQLBin     = "mysql"
+)
+
+// newCommand prepares a command of the MySQL client tools connecting to the
+// provided address. The password is passed via environment to prevent it from
+// showing up in the process list.
+func newCommand(bin, address, username, password string, args ...string) (*exec.Cmd, error) {
+	host, port, err := net.SplitHostPort(address)
+	if err != nil {
+		return nil, err
+	}
+	connArgs := []string{
+		fmt.Sprintf("--host=%s", host),
+		fmt.Sprintf("--port=%s", port),
+		"--protocol=tcp",
+	}
+	if username != "" {
+		connArgs = append(connArgs, fmt.Sprintf("--user=%s", username))
+	}
+	cmd := exec.Command(bin, append(connArgs, args...)...)
+	cmd.Env = os.Environ()
+	if password != "" {
+		cmd.Env = append(cmd.Env, fmt.Sprintf("MYSQL_PWD=%s", password))
+	}
+	return cmd, nil
+}
@@ -0,0 +1,77 @@
+/*
+Copyright 2020 Backup Operator Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mysql
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+
+	"github.com/kubism/backup-operator/pkg/backup"
+	"github.com/kubism/backup-operator/pkg/logger"
+)
+
+func NewMySQLDestination(address, username, password string) (backup.Destination, error) {
+	return &mySQLDestination{
+		Address:  address,
+		Username: username,
+		Password: password,
+		log:      logger.WithName("mysqldst"),
+	}, nil
+}
+
+type mySQLDestination struct {
+	Address  string
+	Username string
+	Password string
+	log      logger.Logger
+}
+
+func (m *mySQLDestination) Store(obj backup.Object) (int64, error) {
+	log := m.log
+	gr, err := gzip.NewReader(obj.Data)
+	if err != nil {
+		return 0, err
+	}
+	defer gr.Close()
+	cmd, err := newCommand(MySQLBin, m.Address, m.Username, m.Password)
+	if err != nil {
+		return 0, err
+	}
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return 0, err
+	}
+	if err := cmd.Start(); err != nil {
+		return 0, err
+	}
+	log.Info("restore starting")
+	written, err := io.Copy(stdin, gr)
+	stdin.Close()
+	if werr := cmd.Wait(); werr != nil {
+		log.Error(werr, "Failed to restore dump", "stderr", stderr.String())
+		return written, fmt.Errorf("%v: %s", werr, stderr.String())
+	}
+	if err != nil {
+		return written, err
+	}
+	log.Info("restore finished", "numBytes", written)
+	return written, nil
+}
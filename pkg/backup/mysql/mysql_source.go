@@ -0,0 +1,83 @@
+/*
+Copyright 2020 Backup Operator Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mysql
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+
+	"github.com/kubism/backup-operator/pkg/backup"
+	"github.com/kubism/backup-operator/pkg/logger"
+)
+
+func NewMySQLSource(address, username, password string, databases []string, archiveName string) (backup.Source, error) {
+	return &mySQLSource{
+		Address:     address,
+		Username:    username,
+		Password:    password,
+		Databases:   databases,
+		ArchiveName: archiveName,
+		log:         logger.WithName("mysqlsrc"),
+	}, nil
+}
+
+type mySQLSource struct {
+	Address     string
+	Username    string
+	Password    string
+	Databases   []string
+	ArchiveName string
+	log         logger.Logger
+}
+
+func (m *mySQLSource) Stream(dst backup.Destination) (int64, error) {
+	log := m.log
+	// single transaction provides a consistent dump of InnoDB tables without
+	// locking them
+	args := []string{
+		"--single-transaction",
+		"--quick",
+		"--routines",
+		"--triggers",
+	}
+	if len(m.Databases) > 0 {
+		args = append(append(args, "--databases"), m.Databases...)
+	} else {
+		args = append(args, "--all-databases")
+	}
+	cmd, err := newCommand(MySQLDumpBin, m.Address, m.Username, m.Password, args...)
+	if err != nil {
+		return 0, err
+	}
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	log.Info("start storing dump")
+	return backup.StreamTo(dst, m.ArchiveName, func(w io.Writer) error {
+		log.Info("starting dump")
+		gw := gzip.NewWriter(w)
+		if err := backup.RunCommand(cmd, gw); err != nil {
+			return fmt.Errorf("%v: %s", err, stderr.String())
+		}
+		if err := gw.Close(); err != nil {
+			return err
+		}
+		log.Info("finished dump")
+		return nil
+	})
+}
@@ -0,0 +1,67 @@
+/*
+Copyright 2020 Backup Operator Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mysql
+
+import (
+	"compress/gzip"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/kubism/backup-operator/pkg/backup/fs"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("MySQLSource", func() {
+	It("should dump to file", func() {
+		src, err := NewMySQLSource(srcAddress, username, password, []string{database}, "backup.sql.gz")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(src).ToNot(BeNil())
+		dir, err := ioutil.TempDir("", "mysqlsrc")
+		Expect(err).ToNot(HaveOccurred())
+		defer os.RemoveAll(dir)
+		fp := filepath.Join(dir, "backup.sql.gz")
+		dst, err := fs.NewDirDestination(dir)
+		Expect(err).ToNot(HaveOccurred())
+		written, err := src.Stream(dst)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(written).To(BeNumerically(">", 0))
+		Expect(fp).Should(BeAnExistingFile())
+		file, err := os.Open(fp)
+		Expect(err).ToNot(HaveOccurred())
+		defer file.Close()
+		gr, err := gzip.NewReader(file)
+		Expect(err).ToNot(HaveOccurred())
+		dump, err := ioutil.ReadAll(gr)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(dump)).Should(ContainSubstring("INSERT INTO `numbers`"))
+	})
+	It("should not store a dump if mysqldump fails", func() {
+		src, err := NewMySQLSource(srcAddress, username, password, []string{"doesnotexist"}, "backup.sql.gz")
+		Expect(err).ToNot(HaveOccurred())
+		dir, err := ioutil.TempDir("", "mysqlsrc")
+		Expect(err).ToNot(HaveOccurred())
+		defer os.RemoveAll(dir)
+		dst, err := fs.NewDirDestination(dir)
+		Expect(err).ToNot(HaveOccurred())
+		_, err = src.Stream(dst)
+		Expect(err).To(HaveOccurred())
+		Expect(ioutil.ReadDir(dir)).To(BeEmpty())
+	})
+})
@@ -0,0 +1,94 @@
+/*
+Copyright 2020 Backup Operator Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mysql
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/kubism/backup-operator/pkg/logger"
+	"github.com/kubism/backup-operator/pkg/testutil"
+	"github.com/onsi/ginkgo/reporters"
+	"github.com/ory/dockertest/v3"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+const (
+	username = "root"
+	password = "testpassword"
+	database = "testing"
+)
+
+var (
+	pool        *dockertest.Pool
+	srcResource *dockertest.Resource
+	dstResource *dockertest.Resource
+	srcAddress  string
+	dstAddress  string
+	srcDSN      string
+	dstDSN      string
+)
+
+func TestMySQL(t *testing.T) {
+	RegisterFailHandler(Fail)
+	junitReporter := reporters.NewJUnitReporter("../../../reports/mysql-junit.xml")
+	RunSpecsWithDefaultAndCustomReporters(t, "MySQL", []Reporter{junitReporter})
+}
+
+var _ = BeforeSuite(func(done Done) {
+	var err error
+	log := logger.WithName("mysqlsetup")
+	By("bootstrapping both mariadbs")
+	pool, err = dockertest.NewPool("")
+	Expect(err).ToNot(HaveOccurred())
+	log.Info("spawn src mariadb container")
+	srcResource, err = pool.Run("mariadb", "10.5", []string{
+		fmt.Sprintf("MYSQL_ROOT_PASSWORD=%s", password),
+		fmt.Sprintf("MYSQL_DATABASE=%s", database),
+	})
+	Expect(err).ToNot(HaveOccurred())
+	srcAddress = fmt.Sprintf("localhost:%s", srcResource.GetPort("3306/tcp"))
+	srcDSN = fmt.Sprintf("%s:%s@tcp(%s)/%s", username, password, srcAddress, database)
+	log.Info("spawn dst mariadb container")
+	dstResource, err = pool.Run("mariadb", "10.5", []string{
+		fmt.Sprintf("MYSQL_ROOT_PASSWORD=%s", password),
+	})
+	Expect(err).ToNot(HaveOccurred())
+	dstAddress = fmt.Sprintf("localhost:%s", dstResource.GetPort("3306/tcp"))
+	dstDSN = fmt.Sprintf("%s:%s@tcp(%s)/%s", username, password, dstAddress, database)
+	log.Info("check src mariadb connection", "address", srcAddress)
+	err = testutil.WaitForMySQL(pool, srcDSN)
+	Expect(err).ToNot(HaveOccurred())
+	log.Info("insert test data", "address", srcAddress)
+	err = testutil.InsertMySQLTestData(srcDSN)
+	Expect(err).ToNot(HaveOccurred())
+	log.Info("check dst mariadb connection", "address", dstAddress)
+	err = testutil.WaitForMySQL(pool, fmt.Sprintf("%s:%s@tcp(%s)/", username, password, dstAddress))
+	Expect(err).ToNot(HaveOccurred())
+	log.Info("mariadb databases ready")
+	close(done)
+}, 60)
+
+var _ = AfterSuite(func() {
+	By("tearing down the test environment")
+	err1 := pool.Purge(srcResource)
+	err2 := pool.Purge(dstResource)
+	Expect(err1).ToNot(HaveOccurred())
+	Expect(err2).ToNot(HaveOccurred())
+})
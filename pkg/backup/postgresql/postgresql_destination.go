@@ -0,0 +1,69 @@
+/*
+Copyright 2020 Backup Operator Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package postgresql
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os/exec"
+
+	"github.com/kubism/backup-operator/pkg/backup"
+	"github.com/kubism/backup-operator/pkg/logger"
+)
+
+func NewPostgreSQLDestination(uri string) (backup.Destination, error) {
+	return &postgreSQLDestination{
+		URI: uri,
+		log: logger.WithName("pgdst"),
+	}, nil
+}
+
+type postgreSQLDestination struct {
+	URI string
+	log logger.Logger
+}
+
+func (p *postgreSQLDestination) Store(obj backup.Object) (int64, error) {
+	log := p.log
+	cmd := exec.Command(PGRestoreBin,
+		"--format=custom",
+		"--exit-on-error",
+		fmt.Sprintf("--dbname=%s", p.URI),
+	)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return 0, err
+	}
+	if err := cmd.Start(); err != nil {
+		return 0, err
+	}
+	log.Info("restore starting")
+	written, err := io.Copy(stdin, obj.Data)
+	stdin.Close()
+	if werr := cmd.Wait(); werr != nil {
+		log.Error(werr, "Failed to restore dump", "stderr", stderr.String())
+		return written, fmt.Errorf("%v: %s", werr, stderr.String())
+	}
+	if err != nil {
+		return written, err
+	}
+	log.Info("restore finished", "numBytes", written)
+	return written, nil
+}
@@ -0,0 +1,61 @@
+/*
+Copyright 2020 Backup Operator Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package postgresql
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os/exec"
+
+	"github.com/kubism/backup-operator/pkg/backup"
+	"github.com/kubism/backup-operator/pkg/logger"
+)
+
+func NewPostgreSQLSource(uri, archiveName string) (backup.Source, error) {
+	return &postgreSQLSource{
+		URI:         uri,
+		ArchiveName: archiveName,
+		log:         logger.WithName("pgsrc"),
+	}, nil
+}
+
+type postgreSQLSource struct {
+	URI         string
+	ArchiveName string
+	log         logger.Logger
+}
+
+func (p *postgreSQLSource) Stream(dst backup.Destination) (int64, error) {
+	log := p.log
+	// custom format is compressed by default and required for pg_restore
+	cmd := exec.Command(PGDumpBin,
+		"--format=custom",
+		fmt.Sprintf("--dbname=%s", p.URI),
+	)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	log.Info("start storing dump")
+	return backup.StreamTo(dst, p.ArchiveName, func(w io.Writer) error {
+		log.Info("starting dump")
+		if err := backup.RunCommand(cmd, w); err != nil {
+			return fmt.Errorf("%v: %s", err, stderr.String())
+		}
+		log.Info("finished dump")
+		return nil
+	})
+}
@@ -0,0 +1,61 @@
+/*
+Copyright 2020 Backup Operator Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package postgresql
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/kubism/backup-operator/pkg/backup/fs"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("PostgreSQLSource", func() {
+	It("should dump to file", func() {
+		src, err := NewPostgreSQLSource(srcURI, "backup.dump")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(src).ToNot(BeNil())
+		dir, err := ioutil.TempDir("", "pgsrc")
+		Expect(err).ToNot(HaveOccurred())
+		defer os.RemoveAll(dir)
+		fp := filepath.Join(dir, "backup.dump")
+		dst, err := fs.NewDirDestination(dir)
+		Expect(err).ToNot(HaveOccurred())
+		written, err := src.Stream(dst)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(written).To(BeNumerically(">", 0))
+		Expect(fp).Should(BeAnExistingFile())
+		fi, err := os.Stat(fp)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(fi.Size()).Should(BeNumerically(">", 0))
+	})
+	It("should not store a dump if pg_dump fails", func() {
+		src, err := NewPostgreSQLSource("postgres://localhost:1/postgres", "backup.dump")
+		Expect(err).ToNot(HaveOccurred())
+		dir, err := ioutil.TempDir("", "pgsrc")
+		Expect(err).ToNot(HaveOccurred())
+		defer os.RemoveAll(dir)
+		dst, err := fs.NewDirDestination(dir)
+		Expect(err).ToNot(HaveOccurred())
+		_, err = src.Stream(dst)
+		Expect(err).To(HaveOccurred())
+		Expect(ioutil.ReadDir(dir)).To(BeEmpty())
+	})
+})
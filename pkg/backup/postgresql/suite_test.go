@@ -0,0 +1,82 @@
+/*
+Copyright 2020 Backup Operator Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package postgresql
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/kubism/backup-operator/pkg/logger"
+	"github.com/kubism/backup-operator/pkg/testutil"
+	"github.com/onsi/ginkgo/reporters"
+	"github.com/ory/dockertest/v3"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+const password = "testpassword"
+
+var (
+	pool        *dockertest.Pool
+	srcResource *dockertest.Resource
+	dstResource *dockertest.Resource
+	srcURI      string
+	dstURI      string
+)
+
+func TestPostgreSQL(t *testing.T) {
+	RegisterFailHandler(Fail)
+	junitReporter := reporters.NewJUnitReporter("../../../reports/postgresql-junit.xml")
+	RunSpecsWithDefaultAndCustomReporters(t, "PostgreSQL", []Reporter{junitReporter})
+}
+
+var _ = BeforeSuite(func(done Done) {
+	var err error
+	log := logger.WithName("pgsetup")
+	By("bootstrapping both postgresqls")
+	pool, err = dockertest.NewPool("")
+	Expect(err).ToNot(HaveOccurred())
+	env := []string{fmt.Sprintf("POSTGRES_PASSWORD=%s", password)}
+	log.Info("spawn src postgres container")
+	srcResource, err = pool.Run("postgres", "12", env)
+	Expect(err).ToNot(HaveOccurred())
+	srcURI = fmt.Sprintf("postgres://postgres:%s@localhost:%s/postgres?sslmode=disable", password, srcResource.GetPort("5432/tcp"))
+	log.Info("spawn dst postgres container")
+	dstResource, err = pool.Run("postgres", "12", env)
+	Expect(err).ToNot(HaveOccurred())
+	dstURI = fmt.Sprintf("postgres://postgres:%s@localhost:%s/postgres?sslmode=disable", password, dstResource.GetPort("5432/tcp"))
+	log.Info("check src postgres connection", "uri", srcURI)
+	err = testutil.WaitForPostgreSQL(pool, srcURI)
+	Expect(err).ToNot(HaveOccurred())
+	log.Info("insert test data", "uri", srcURI)
+	err = testutil.InsertPostgreSQLTestData(srcURI)
+	Expect(err).ToNot(HaveOccurred())
+	log.Info("check dst postgres connection", "uri", dstURI)
+	err = testutil.WaitForPostgreSQL(pool, dstURI)
+	Expect(err).ToNot(HaveOccurred())
+	log.Info("postgres databases ready")
+	close(done)
+}, 60)
+
+var _ = AfterSuite(func() {
+	By("tearing down the test environment")
+	err1 := pool.Purge(srcResource)
+	err2 := pool.Purge(dstResource)
+	Expect(err1).ToNot(HaveOccurred())
+	Expect(err2).ToNot(HaveOccurred())
+})
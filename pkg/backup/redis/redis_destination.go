@@ -0,0 +1,232 @@
+/*
+Copyright 2020 Backup Operator Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package redis
+
+import (
+	"bufio"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/kubism/backup-operator/pkg/backup"
+	"github.com/kubism/backup-operator/pkg/logger"
+)
+
+const DefaultTimeout = 5 * time.Minute
+
+type RedisDestinationConf struct {
+	// Address of the empty redis instance to restore into
+	Address  string
+	Username string
+	Password string
+	// Address to listen on for the replication connection of redis
+	ListenAddress string
+	// Address redis uses to connect to the destination. Defaults to the
+	// address of the listener.
+	AdvertiseAddress string
+	// Maximum duration to wait for redis to connect and load the snapshot
+	Timeout time.Duration
+}
+
+func NewRedisDestination(conf *RedisDestinationConf) (backup.Destination, error) {
+	timeout := conf.Timeout
+	if timeout == 0 {
+		timeout = DefaultTimeout
+	}
+	return &redisDestination{
+		Address:          conf.Address,
+		Username:         conf.Username,
+		Password:         conf.Password,
+		ListenAddress:    conf.ListenAddress,
+		AdvertiseAddress: conf.AdvertiseAddress,
+		Timeout:          timeout,
+		log:              logger.WithName("redisdst"),
+	}, nil
+}
+
+type redisDestination struct {
+	Address          string
+	Username         string
+	Password         string
+	ListenAddress    string
+	AdvertiseAddress string
+	Timeout          time.Duration
+	log              logger.Logger
+}
+
+// Store acts as master of the redis instance for a single full
+// synchronization, which makes redis load the snapshot as its dataset.
+func (d *redisDestination) Store(obj backup.Object) (int64, error) {
+	log := d.log
+	c, err := dial(d.Address, d.Username, d.Password)
+	if err != nil {
+		log.Error(err, "Could not connect to redis")
+		return 0, err
+	}
+	defer c.Close()
+	size, err := c.do("DBSIZE")
+	if err != nil {
+		return 0, err
+	}
+	if size != "0" {
+		return 0, fmt.Errorf("refusing to restore into non-empty redis with %s keys", size)
+	}
+	ln, err := net.Listen("tcp", d.ListenAddress)
+	if err != nil {
+		return 0, err
+	}
+	defer ln.Close()
+	advertised := d.AdvertiseAddress
+	if advertised == "" {
+		advertised = ln.Addr().String()
+	}
+	host, port, err := net.SplitHostPort(advertised)
+	if err != nil {
+		return 0, err
+	}
+	deadline := time.Now().Add(d.Timeout)
+	log.Info("restore starting", "advertised", advertised)
+	if _, err := c.do("REPLICAOF", host, port); err != nil {
+		return 0, err
+	}
+	defer func() {
+		if _, err := c.do("REPLICAOF", "NO", "ONE"); err != nil {
+			log.Error(err, "Failed to promote redis to master again")
+		}
+	}()
+	if err := ln.(*net.TCPListener).SetDeadline(deadline); err != nil {
+		return 0, err
+	}
+	nc, err := ln.Accept()
+	if err != nil {
+		return 0, err
+	}
+	defer nc.Close()
+	replica := &conn{
+		Conn: nc,
+		r:    bufio.NewReader(nc),
+	}
+	if err := handshake(replica); err != nil {
+		log.Error(err, "Replication handshake failed")
+		return 0, err
+	}
+	written, err := transfer(replica, obj.Data)
+	if err != nil {
+		log.Error(err, "Failed to transfer snapshot to redis")
+		return written, err
+	}
+	// discard acknowledgements sent by redis while loading
+	go func() {
+		_, _ = io.Copy(ioutil.Discard, replica.r)
+	}()
+	if err := d.waitForSync(c, deadline); err != nil {
+		log.Error(err, "Failed to load snapshot into redis")
+		return written, err
+	}
+	log.Info("restore finished", "numBytes", written)
+	return written, nil
+}
+
+// handshake answers the commands of redis until it requests a
+// synchronization, while making sure it supports diskless transfers.
+func handshake(replica *conn) error {
+	eof := false
+	for {
+		args, err := replica.readCommand()
+		if err != nil {
+			return err
+		}
+		if len(args) == 0 {
+			continue
+		}
+		switch strings.ToUpper(args[0]) {
+		case "PING":
+			_, err = io.WriteString(replica, "+PONG\r\n")
+		case "AUTH":
+			_, err = io.WriteString(replica, "+OK\r\n")
+		case "REPLCONF":
+			for i := 1; i+1 < len(args); i++ {
+				if strings.EqualFold(args[i], "capa") && strings.EqualFold(args[i+1], "eof") {
+					eof = true
+				}
+			}
+			_, err = io.WriteString(replica, "+OK\r\n")
+		case "PSYNC", "SYNC":
+			if !eof {
+				return fmt.Errorf("redis does not support diskless synchronization")
+			}
+			return nil
+		default:
+			_, err = fmt.Fprintf(replica, "-ERR unsupported command '%s'\r\n", args[0])
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// transfer sends the snapshot using the EOF-marker format, so the size of the
+// snapshot does not have to be known in advance.
+func transfer(replica *conn, data io.Reader) (int64, error) {
+	replID, err := randomID()
+	if err != nil {
+		return 0, err
+	}
+	mark, err := randomID()
+	if err != nil {
+		return 0, err
+	}
+	if _, err := fmt.Fprintf(replica, "+FULLRESYNC %s 0\r\n$EOF:%s\r\n", replID, mark); err != nil {
+		return 0, err
+	}
+	written, err := io.Copy(replica, data)
+	if err != nil {
+		return written, err
+	}
+	_, err = io.WriteString(replica, mark)
+	return written, err
+}
+
+func (d *redisDestination) waitForSync(c *conn, deadline time.Time) error {
+	for time.Now().Before(deadline) {
+		info, err := c.do("INFO", "replication")
+		if err != nil {
+			return err
+		}
+		if infoField(info, "master_link_status") == "up" &&
+			infoField(info, "master_sync_in_progress") == "0" {
+			return nil
+		}
+		time.Sleep(1 * time.Second)
+	}
+	return fmt.Errorf("redis did not load snapshot within %v", d.Timeout)
+}
+
+// randomID returns 40 random hex characters as used by redis for the
+// replication ID and EOF-marker.
+func randomID() (string, error) {
+	buf := make([]byte, 20)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
@@ -0,0 +1,57 @@
+/*
+Copyright 2020 Backup Operator Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package redis
+
+import (
+	"net"
+
+	"github.com/kubism/backup-operator/pkg/testutil"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("RedisDestination", func() {
+	It("should restore snapshot", func() {
+		src, err := NewRedisSource(srcAddress, "", password, "dump.rdb")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(src).ToNot(BeNil())
+		dst, err := NewRedisDestination(&RedisDestinationConf{
+			Address:       dstAddress,
+			Password:      password,
+			ListenAddress: net.JoinHostPort(dstGateway, "0"),
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(dst).ToNot(BeNil())
+		written, err := src.Stream(dst)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(written).To(BeNumerically(">", 0))
+		err = testutil.FindRedisTestData(dstAddress, password)
+		Expect(err).ToNot(HaveOccurred())
+	})
+	It("should refuse to restore into non-empty redis", func() {
+		src, err := NewRedisSource(srcAddress, "", password, "dump.rdb")
+		Expect(err).ToNot(HaveOccurred())
+		dst, err := NewRedisDestination(&RedisDestinationConf{
+			Address:  srcAddress,
+			Password: password,
+		})
+		Expect(err).ToNot(HaveOccurred())
+		_, err = src.Stream(dst)
+		Expect(err).To(HaveOccurred())
+	})
+})
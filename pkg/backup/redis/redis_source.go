@@ -0,0 +1,114 @@
+/*
+Copyright 2020 Backup Operator Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package redis
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/kubism/backup-operator/pkg/backup"
+	"github.com/kubism/backup-operator/pkg/logger"
+)
+
+// rdbMagic is the prefix of every RDB file
+var rdbMagic = []byte("REDIS")
+
+func NewRedisSource(address, username, password, snapName string) (backup.Source, error) {
+	return &redisSource{
+		Address:  address,
+		Username: username,
+		Password: password,
+		SnapName: snapName,
+		log:      logger.WithName("redissrc"),
+	}, nil
+}
+
+type redisSource struct {
+	Address  string
+	Username string
+	Password string
+	SnapName string
+	log      logger.Logger
+}
+
+// Stream connects to redis as replica and requests a full resynchronization,
+// which results in redis sending a RDB snapshot of its current dataset.
+func (s *redisSource) Stream(dst backup.Destination) (int64, error) {
+	log := s.log
+	c, err := dial(s.Address, s.Username, s.Password)
+	if err != nil {
+		log.Error(err, "Could not connect to redis")
+		return 0, err
+	}
+	defer c.Close()
+	if _, err := c.do("PING"); err != nil {
+		return 0, err
+	}
+	// request a full resync without announcing any capabilities, so redis
+	// prefixes the snapshot with its length
+	reply, err := c.do("PSYNC", "?", "-1")
+	if err != nil {
+		return 0, err
+	}
+	if !strings.HasPrefix(reply, "FULLRESYNC") {
+		return 0, fmt.Errorf("unexpected reply to PSYNC: %q", reply)
+	}
+	log.Info("full resync requested, waiting for snapshot", "reply", reply)
+	size, err := readSnapshotSize(c)
+	if err != nil {
+		return 0, err
+	}
+	log.Info("receiving snapshot", "size", size)
+	magic := make([]byte, len(rdbMagic))
+	if _, err := io.ReadFull(c.r, magic); err != nil {
+		return 0, err
+	}
+	if !bytes.Equal(magic, rdbMagic) {
+		return 0, fmt.Errorf("received snapshot is not a valid RDB file")
+	}
+	data := io.MultiReader(bytes.NewReader(magic), io.LimitReader(c.r, size-int64(len(magic))))
+	return backup.StreamTo(dst, s.SnapName, func(w io.Writer) error {
+		log.Info("starting dump")
+		numBytes, err := io.Copy(w, data)
+		if err == nil && numBytes != size {
+			err = fmt.Errorf("snapshot truncated after %d of %d bytes", numBytes, size)
+		}
+		log.Info("finished dump", "numBytes", numBytes)
+		return err
+	})
+}
+
+// readSnapshotSize skips the newlines redis sends to keep the connection
+// alive while the snapshot is created and returns the announced size.
+func readSnapshotSize(c *conn) (int64, error) {
+	for {
+		line, err := c.readLine()
+		if err != nil {
+			return 0, err
+		}
+		if line == "" {
+			continue
+		}
+		if !strings.HasPrefix(line, "$") || strings.HasPrefix(line, "$EOF:") {
+			return 0, fmt.Errorf("unexpected snapshot header: %q", line)
+		}
+		return strconv.ParseInt(line[1:], 10, 64)
+	}
+}
@@ -0,0 +1,92 @@
+/*
+Copyright 2020 Backup Operator Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package redis
+
+import (
+	"bufio"
+	"io"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+
+	"github.com/kubism/backup-operator/pkg/backup/fs"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("RedisSource", func() {
+	It("should dump to file", func() {
+		src, err := NewRedisSource(srcAddress, "", password, "dump.rdb")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(src).ToNot(BeNil())
+		dir, err := ioutil.TempDir("", "redissrc")
+		Expect(err).ToNot(HaveOccurred())
+		defer os.RemoveAll(dir)
+		fp := filepath.Join(dir, "dump.rdb")
+		dst, err := fs.NewDirDestination(dir)
+		Expect(err).ToNot(HaveOccurred())
+		written, err := src.Stream(dst)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(written).To(BeNumerically(">", 0))
+		Expect(fp).Should(BeAnExistingFile())
+		data, err := ioutil.ReadFile(fp)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(data[:5])).To(Equal("REDIS"))
+	})
+	It("should fail with wrong password", func() {
+		src, err := NewRedisSource(srcAddress, "", "wrong", "dump.rdb")
+		Expect(err).ToNot(HaveOccurred())
+		dir, err := ioutil.TempDir("", "redissrc")
+		Expect(err).ToNot(HaveOccurred())
+		defer os.RemoveAll(dir)
+		dst, err := fs.NewDirDestination(dir)
+		Expect(err).ToNot(HaveOccurred())
+		_, err = src.Stream(dst)
+		Expect(err).To(HaveOccurred())
+	})
+	It("should not store truncated snapshots", func() {
+		// fake master, which closes the connection during the transfer
+		l, err := net.Listen("tcp", "127.0.0.1:0")
+		Expect(err).ToNot(HaveOccurred())
+		defer l.Close()
+		go func() {
+			defer GinkgoRecover()
+			nc, err := l.Accept()
+			Expect(err).ToNot(HaveOccurred())
+			defer nc.Close()
+			c := &conn{Conn: nc, r: bufio.NewReader(nc)}
+			for _, reply := range []string{"+PONG\r\n", "+FULLRESYNC 0123456789 0\r\n$1024\r\nREDIS0009partial"} {
+				_, err := c.readCommand()
+				Expect(err).ToNot(HaveOccurred())
+				_, err = io.WriteString(nc, reply)
+				Expect(err).ToNot(HaveOccurred())
+			}
+		}()
+		src, err := NewRedisSource(l.Addr().String(), "", "", "dump.rdb")
+		Expect(err).ToNot(HaveOccurred())
+		dir, err := ioutil.TempDir("", "redissrc")
+		Expect(err).ToNot(HaveOccurred())
+		defer os.RemoveAll(dir)
+		dst, err := fs.NewDirDestination(dir)
+		Expect(err).ToNot(HaveOccurred())
+		_, err = src.Stream(dst)
+		Expect(err).To(MatchError(ContainSubstring("truncated")))
+		Expect(ioutil.ReadDir(dir)).To(BeEmpty())
+	})
+})
@@ -0,0 +1,91 @@
+/*
+Copyright 2020 Backup Operator Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package redis
+
+import (
+	"fmt"
+	"net"
+	"testing"
+
+	"github.com/kubism/backup-operator/pkg/logger"
+	"github.com/kubism/backup-operator/pkg/testutil"
+	"github.com/onsi/ginkgo/reporters"
+	"github.com/ory/dockertest/v3"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+const password = "testpassword"
+
+var (
+	pool        *dockertest.Pool
+	srcResource *dockertest.Resource
+	dstResource *dockertest.Resource
+	srcAddress  string
+	dstAddress  string
+	dstGateway  string
+)
+
+func TestRedis(t *testing.T) {
+	RegisterFailHandler(Fail)
+	junitReporter := reporters.NewJUnitReporter("../../../reports/redis-junit.xml")
+	RunSpecsWithDefaultAndCustomReporters(t, "Redis", []Reporter{junitReporter})
+}
+
+var _ = BeforeSuite(func(done Done) {
+	var err error
+	log := logger.WithName("redissetup")
+	By("bootstrapping both redis instances")
+	pool, err = dockertest.NewPool("")
+	Expect(err).ToNot(HaveOccurred())
+	options := &dockertest.RunOptions{
+		Repository: "redis",
+		Tag:        "6",
+		Cmd:        []string{"redis-server", "--requirepass", password, "--masterauth", password},
+	}
+	log.Info("spawn src redis container")
+	srcResource, err = pool.RunWithOptions(options)
+	Expect(err).ToNot(HaveOccurred())
+	srcAddress = fmt.Sprintf("localhost:%s", srcResource.GetPort("6379/tcp"))
+	log.Info("spawn dst redis container")
+	dstResource, err = pool.RunWithOptions(options)
+	Expect(err).ToNot(HaveOccurred())
+	dstAddress = fmt.Sprintf("localhost:%s", dstResource.GetPort("6379/tcp"))
+	// dst redis has to connect back to the destination for the restore
+	dstGateway = dstResource.Container.NetworkSettings.Gateway
+	Expect(net.ParseIP(dstGateway)).ToNot(BeNil())
+	log.Info("check src redis connection", "address", srcAddress)
+	err = testutil.WaitForRedis(pool, srcAddress, password)
+	Expect(err).ToNot(HaveOccurred())
+	log.Info("insert test data", "address", srcAddress)
+	err = testutil.InsertRedisTestData(srcAddress, password)
+	Expect(err).ToNot(HaveOccurred())
+	log.Info("check dst redis connection", "address", dstAddress)
+	err = testutil.WaitForRedis(pool, dstAddress, password)
+	Expect(err).ToNot(HaveOccurred())
+	log.Info("redis instances ready")
+	close(done)
+}, 60)
+
+var _ = AfterSuite(func() {
+	By("tearing down the test environment")
+	err1 := pool.Purge(srcResource)
+	err2 := pool.Purge(dstResource)
+	Expect(err1).ToNot(HaveOccurred())
+	Expect(err2).ToNot(HaveOccurred())
+})
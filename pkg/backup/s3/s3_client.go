@@ -0,0 +1,73 @@
+/*
+Copyright 2020 Backup Operator Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package s3
+
+import (
+	"crypto/tls"
+	"net/http"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+)
+
+func newClient(endpoint, accessKey, secretKey string, disableSSL, insecureSkipVerify bool) (*session.Session, *s3.S3, error) {
+	newSession, err := session.NewSession(&aws.Config{
+		Credentials:      credentials.NewStaticCredentials(accessKey, secretKey, ""),
+		Endpoint:         aws.String(endpoint),
+		Region:           aws.String("us-east-1"),
+		DisableSSL:       aws.Bool(disableSSL),
+		S3ForcePathStyle: aws.Bool(true),
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	tr := &http.Transport{
+		TLSClientConfig: &tls.Config{InsecureSkipVerify: insecureSkipVerify},
+	}
+	cl := &http.Client{Transport: tr}
+	return newSession, s3.New(newSession, aws.NewConfig().WithHTTPClient(cl)), nil
+}
+
+// ensureBucket creates the bucket, if it does not exist
+func ensureBucket(client *s3.S3, bucket string) error {
+	_, err := client.CreateBucket(&s3.CreateBucketInput{
+		Bucket: aws.String(bucket),
+	})
+	if err != nil { // If bucket already exists ignore error
+		if aerr, ok := err.(awserr.Error); ok {
+			if aerr.Code() != s3.ErrCodeBucketAlreadyExists && aerr.Code() != s3.ErrCodeBucketAlreadyOwnedByYou {
+				return err
+			}
+		} else {
+			return err
+		}
+	}
+	return nil
+}
+
+// sseCustomerAlgorithm returns the algorithm for server-side encryption with
+// customer keys, while falling back to the default algorithm
+func sseCustomerAlgorithm(algorithm string) *string {
+	if algorithm == "" {
+		return aws.String(DefaultEncryptionAlgorithm)
+	}
+	return aws.String(algorithm)
+}
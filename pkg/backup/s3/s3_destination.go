@@ -17,17 +17,15 @@ limitations under the License.
 package s3
 
 import (
-	"crypto/tls"
-	"net/http"
+	"path"
 	"path/filepath"
 	"sort"
+	"strings"
 
 	"github.com/kubism/backup-operator/pkg/backup"
 	"github.com/kubism/backup-operator/pkg/logger"
 
 	"github.com/aws/aws-sdk-go/aws"
-	"github.com/aws/aws-sdk-go/aws/awserr"
-	"github.com/aws/aws-sdk-go/aws/credentials"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/s3"
 	"github.com/aws/aws-sdk-go/service/s3/s3manager"
@@ -47,35 +45,12 @@ type S3DestinationConf struct {
 }
 
 func NewS3Destination(conf *S3DestinationConf) (*S3Destination, error) {
-	newSession, err := session.NewSession(&aws.Config{
-		Credentials:      credentials.NewStaticCredentials(conf.AccessKey, conf.SecretKey, ""),
-		Endpoint:         aws.String(conf.Endpoint),
-		Region:           aws.String("us-east-1"),
-		DisableSSL:       aws.Bool(conf.DisableSSL),
-		S3ForcePathStyle: aws.Bool(true),
-	})
+	newSession, client, err := newClient(conf.Endpoint, conf.AccessKey, conf.SecretKey, conf.DisableSSL, conf.InsecureSkipVerify)
 	if err != nil {
 		return nil, err
 	}
-
-	tr := &http.Transport{
-		TLSClientConfig: &tls.Config{InsecureSkipVerify: conf.InsecureSkipVerify},
-	}
-	cl := &http.Client{Transport: tr}
-	client := s3.New(newSession, aws.NewConfig().WithHTTPClient(cl))
-
-	// Create bucket, if not exists
-	_, err = client.CreateBucket(&s3.CreateBucketInput{
-		Bucket: aws.String(conf.Bucket),
-	})
-	if err != nil { // If bucket already exists ignore error
-		if aerr, ok := err.(awserr.Error); ok {
-			if aerr.Code() != s3.ErrCodeBucketAlreadyExists && aerr.Code() != s3.ErrCodeBucketAlreadyOwnedByYou {
-				return nil, err
-			}
-		} else {
-			return nil, err
-		}
+	if err := ensureBucket(client, conf.Bucket); err != nil {
+		return nil, err
 	}
 	return &S3Destination{
 		Session:             newSession,
@@ -181,6 +156,36 @@ func (s *S3Destination) EnsureRetention(max int) error {
 	return nil
 }
 
+// ListObjectNames returns the names of all objects of the destination
+// relative to its prefix
+func (s *S3Destination) ListObjectNames() ([]string, error) {
+	prefix := s.Prefix + "/"
+	input := &s3.ListObjectsInput{
+		Bucket: &s.Bucket,
+		Prefix: &prefix,
+	}
+	names := []string{}
+	err := s.Client.ListObjectsPages(input,
+		func(page *s3.ListObjectsOutput, lastPage bool) bool {
+			for _, obj := range page.Contents {
+				names = append(names, strings.TrimPrefix(*obj.Key, prefix))
+			}
+			return true
+		})
+	return names, err
+}
+
+// DeleteObjects deletes the objects with the provided names relative to the
+// prefix of the destination
+func (s *S3Destination) DeleteObjects(names []string) error {
+	for _, name := range names {
+		if err := s.deleteObject(path.Join(s.Prefix, name)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 type sortableObjectSlice []*s3.Object
 
 func (s sortableObjectSlice) Len() int {
@@ -24,6 +24,7 @@ import (
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/s3"
 	"github.com/aws/aws-sdk-go/service/s3/s3manager"
+	"github.com/kubism/backup-operator/pkg/backup"
 	"github.com/kubism/backup-operator/pkg/backup/mem"
 	"github.com/kubism/backup-operator/pkg/backup/mongodb"
 	"github.com/kubism/backup-operator/pkg/testutil"
@@ -157,9 +158,28 @@ var _ = Describe("S3Destination", func() {
 		Entry("4 out of 5", 4, 5),
 		Entry("5 out of 12", 5, 12),
 	)
+	It("should list and delete objects by name", func() {
+		conf := &S3DestinationConf{
+			Endpoint:           endpoint,
+			AccessKey:          accessKeyID,
+			SecretKey:          secretAccessKey,
+			InsecureSkipVerify: true,
+			Bucket:             "names",
+			Prefix:             "ns/plan",
+		}
+		dst, err := NewS3Destination(conf)
+		Expect(err).ToNot(HaveOccurred())
+		for _, name := range []string{"backup.tgz", "oplog/a.bson.gz", "oplog/b.bson.gz"} {
+			_, err := dst.Store(backup.Object{ID: name, Data: bytes.NewReader([]byte(name))})
+			Expect(err).ToNot(HaveOccurred())
+		}
+		Expect(dst.ListObjectNames()).To(ConsistOf("backup.tgz", "oplog/a.bson.gz", "oplog/b.bson.gz"))
+		Expect(dst.DeleteObjects([]string{"oplog/a.bson.gz"})).Should(Succeed())
+		Expect(dst.ListObjectNames()).To(ConsistOf("backup.tgz", "oplog/b.bson.gz"))
+	})
 	It("should stream from MongoDBSource to S3Destination and back", func() {
 		name := "backup.tgz"
-		src, err := mongodb.NewMongoDBSource(srcURI, "", name)
+		src, err := mongodb.NewMongoDBSource(&mongodb.MongoDBSourceConf{URI: srcURI}, name)
 		Expect(err).ToNot(HaveOccurred())
 		Expect(src).ToNot(BeNil())
 		bucket := "bucketc"
@@ -200,7 +220,7 @@ var _ = Describe("S3Destination", func() {
 		src, err = NewS3Source(confSrc)
 		Expect(err).ToNot(HaveOccurred())
 		Expect(src).ToNot(BeNil())
-		mdst, err := mongodb.NewMongoDBDestination(dstURI)
+		mdst, err := mongodb.NewMongoDBDestination(&mongodb.MongoDBDestinationConf{URI: dstURI})
 		Expect(err).ToNot(HaveOccurred())
 		Expect(mdst).ToNot(BeNil())
 		_, err = src.Stream(mdst)
@@ -211,7 +231,7 @@ var _ = Describe("S3Destination", func() {
 
 	It("should stream from MongoDBSource to encrypted S3Destination and back", func() {
 		name := "backup.tgz"
-		src, err := mongodb.NewMongoDBSource(srcURI, "", name)
+		src, err := mongodb.NewMongoDBSource(&mongodb.MongoDBSourceConf{URI: srcURI}, name)
 		Expect(err).ToNot(HaveOccurred())
 		Expect(src).ToNot(BeNil())
 		bucket := "buckete"
@@ -246,7 +266,7 @@ var _ = Describe("S3Destination", func() {
 		src, err = NewS3Source(confSrc)
 		Expect(err).ToNot(HaveOccurred())
 		Expect(src).ToNot(BeNil())
-		mdst, err := mongodb.NewMongoDBDestination(dstURI)
+		mdst, err := mongodb.NewMongoDBDestination(&mongodb.MongoDBDestinationConf{URI: dstURI})
 		Expect(err).ToNot(HaveOccurred())
 		Expect(mdst).ToNot(BeNil())
 		_, err = src.Stream(mdst)
@@ -0,0 +1,171 @@
+/*
+Copyright 2020 Backup Operator Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package s3
+
+import (
+	"bytes"
+	"encoding/json"
+	"path"
+	"strings"
+
+	"github.com/kubism/backup-operator/pkg/backup"
+	"github.com/kubism/backup-operator/pkg/logger"
+
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+)
+
+type S3PrefixSourceConf struct {
+	Endpoint            string
+	AccessKey           string
+	SecretKey           string
+	EncryptionKey       *string
+	EncryptionAlgorithm string
+	DisableSSL          bool
+	InsecureSkipVerify  bool
+	Bucket              string
+	Prefix              string
+	// Name of the snapshot, which is used as prefix for all stored objects
+	SnapshotName string
+	// Manifest of the previous snapshot. If provided, objects with unchanged
+	// ETag are skipped and referenced in the new manifest instead.
+	Previous *S3Manifest
+}
+
+// NewS3PrefixSource returns a source, which stores all objects of a prefix
+// as separate objects under the prefix of the snapshot name. Afterwards a
+// manifest of the snapshot is stored as <snapshot name>.json.
+func NewS3PrefixSource(conf *S3PrefixSourceConf) (*S3PrefixSource, error) {
+	newSession, client, err := newClient(conf.Endpoint, conf.AccessKey, conf.SecretKey, conf.DisableSSL, conf.InsecureSkipVerify)
+	if err != nil {
+		return nil, err
+	}
+	// stop at a path boundary, so e.g. "data" does not include "data2/x"
+	prefix := conf.Prefix
+	if prefix != "" && !strings.HasSuffix(prefix, "/") {
+		prefix += "/"
+	}
+	return &S3PrefixSource{
+		Session:             newSession,
+		Client:              client,
+		EncryptionKey:       conf.EncryptionKey,
+		EncryptionAlgorithm: conf.EncryptionAlgorithm,
+		Bucket:              conf.Bucket,
+		Prefix:              prefix,
+		SnapshotName:        conf.SnapshotName,
+		Previous:            conf.Previous,
+		log:                 logger.WithName("s3prefixsrc"),
+	}, nil
+}
+
+type S3PrefixSource struct {
+	Session             *session.Session
+	Client              *s3.S3
+	Bucket              string
+	Prefix              string
+	SnapshotName        string
+	Previous            *S3Manifest
+	EncryptionKey       *string
+	EncryptionAlgorithm string
+	log                 logger.Logger
+}
+
+func (s *S3PrefixSource) Stream(dst backup.Destination) (int64, error) {
+	log := s.log
+	manifest := &S3Manifest{
+		Snapshot: s.SnapshotName,
+		Objects:  map[string]S3ManifestEntry{},
+	}
+	var written int64
+	var streamErr error
+	// NOTE: using V1 list method is intentional as V2 malfunctioned on older ceph s3 installations
+	input := &s3.ListObjectsInput{
+		Bucket: &s.Bucket,
+		Prefix: &s.Prefix,
+	}
+	log.Info("starting dump", "bucket", s.Bucket, "prefix", s.Prefix)
+	err := s.Client.ListObjectsPages(input,
+		func(page *s3.ListObjectsOutput, lastPage bool) bool {
+			for _, obj := range page.Contents {
+				n, err := s.streamObject(dst, obj, manifest)
+				written += n
+				if err != nil {
+					streamErr = err
+					return false
+				}
+			}
+			return true
+		})
+	if err != nil {
+		return written, err
+	}
+	if streamErr != nil {
+		return written, streamErr
+	}
+	log.Info("finished dump", "numObjects", len(manifest.Objects), "numBytes", written)
+	raw, err := json.Marshal(manifest)
+	if err != nil {
+		return written, err
+	}
+	_, err = dst.Store(backup.Object{
+		ID:   s.SnapshotName + S3ManifestSuffix,
+		Data: bytes.NewReader(raw),
+	})
+	return written, err
+}
+
+func (s *S3PrefixSource) streamObject(dst backup.Destination, obj *s3.Object, manifest *S3Manifest) (int64, error) {
+	key := strings.TrimPrefix(*obj.Key, s.Prefix)
+	if key == "" || strings.HasSuffix(key, "/") { // ignore folder placeholders
+		return 0, nil
+	}
+	etag := ""
+	if obj.ETag != nil {
+		etag = *obj.ETag
+	}
+	if s.Previous != nil {
+		if previous, ok := s.Previous.Objects[key]; ok && etag != "" && previous.ETag == etag {
+			manifest.Objects[key] = previous
+			return 0, nil
+		}
+	}
+	params := &s3.GetObjectInput{
+		Bucket: &s.Bucket,
+		Key:    obj.Key,
+	}
+	if s.EncryptionKey != nil {
+		params.SSECustomerAlgorithm = sseCustomerAlgorithm(s.EncryptionAlgorithm)
+		params.SSECustomerKey = s.EncryptionKey
+	}
+	res, err := s.Client.GetObject(params)
+	if err != nil {
+		return 0, err
+	}
+	defer res.Body.Close()
+	written, err := dst.Store(backup.Object{
+		ID:   path.Join(s.SnapshotName, key),
+		Data: res.Body,
+	})
+	if err != nil {
+		return written, err
+	}
+	manifest.Objects[key] = S3ManifestEntry{
+		ETag:     etag,
+		Snapshot: s.SnapshotName,
+	}
+	return written, nil
+}
@@ -0,0 +1,127 @@
+/*
+Copyright 2020 Backup Operator Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package s3
+
+import (
+	"bytes"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("S3PrefixSource", func() {
+	It("should store snapshots of prefix incrementally", func() {
+		srcBucket := "bucketprefixsrc"
+		dstBucket := "bucketprefixdst"
+		dst, err := NewS3Destination(&S3DestinationConf{
+			Endpoint:           endpoint,
+			AccessKey:          accessKeyID,
+			SecretKey:          secretAccessKey,
+			InsecureSkipVerify: true,
+			Bucket:             dstBucket,
+			Prefix:             "ns/plan",
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ensureBucket(dst.Client, srcBucket)).Should(Succeed())
+		put := func(key, data string) {
+			_, err := dst.Client.PutObject(&s3.PutObjectInput{
+				Body:   bytes.NewReader([]byte(data)),
+				Bucket: aws.String(srcBucket),
+				Key:    aws.String(key),
+			})
+			Expect(err).ToNot(HaveOccurred())
+		}
+		get := func(key string) string {
+			res, err := dst.Client.GetObject(&s3.GetObjectInput{
+				Bucket: aws.String(dstBucket),
+				Key:    aws.String(key),
+			})
+			Expect(err).ToNot(HaveOccurred())
+			defer res.Body.Close()
+			buf := new(bytes.Buffer)
+			_, err = buf.ReadFrom(res.Body)
+			Expect(err).ToNot(HaveOccurred())
+			return buf.String()
+		}
+		exists := func(key string) bool {
+			_, err := dst.Client.HeadObject(&s3.HeadObjectInput{
+				Bucket: aws.String(dstBucket),
+				Key:    aws.String(key),
+			})
+			return err == nil
+		}
+		snapshot := func(name string, incremental bool) {
+			var previous *S3Manifest
+			if incremental {
+				previous, err = dst.LoadLatestManifest()
+				Expect(err).ToNot(HaveOccurred())
+			}
+			src, err := NewS3PrefixSource(&S3PrefixSourceConf{
+				Endpoint:           endpoint,
+				AccessKey:          accessKeyID,
+				SecretKey:          secretAccessKey,
+				InsecureSkipVerify: true,
+				Bucket:             srcBucket,
+				Prefix:             "uploads",
+				SnapshotName:       name,
+				Previous:           previous,
+			})
+			Expect(err).ToNot(HaveOccurred())
+			_, err = src.Stream(dst)
+			Expect(err).ToNot(HaveOccurred())
+		}
+		put("uploads/a.txt", "a")
+		put("uploads/nested/b.txt", "b")
+		put("other/c.txt", "c")
+		put("uploads2/d.txt", "d")
+		snapshot("backup-1", true)
+		Expect(get("ns/plan/backup-1/a.txt")).To(Equal("a"))
+		Expect(get("ns/plan/backup-1/nested/b.txt")).To(Equal("b"))
+		Expect(exists("ns/plan/backup-1/c.txt")).To(BeFalse())
+		Expect(exists("ns/plan/backup-1/2/d.txt")).To(BeFalse())
+		Expect(exists("ns/plan/backup-1.json")).To(BeTrue())
+
+		put("uploads/a.txt", "changed")
+		snapshot("backup-2", true)
+		Expect(get("ns/plan/backup-2/a.txt")).To(Equal("changed"))
+		Expect(exists("ns/plan/backup-2/nested/b.txt")).To(BeFalse())
+		manifest, err := dst.LoadLatestManifest()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(manifest.Snapshot).To(Equal("backup-2"))
+		Expect(manifest.Objects).To(HaveLen(2))
+		Expect(manifest.Objects["a.txt"].Snapshot).To(Equal("backup-2"))
+		Expect(manifest.Objects["nested/b.txt"].Snapshot).To(Equal("backup-1"))
+
+		snapshot("backup-3", false)
+		Expect(get("ns/plan/backup-3/nested/b.txt")).To(Equal("b"))
+
+		Expect(dst.EnsureSnapshotRetention(2)).Should(Succeed())
+		Expect(exists("ns/plan/backup-1.json")).To(BeFalse())
+		Expect(exists("ns/plan/backup-1/nested/b.txt")).To(BeTrue()) // referenced by backup-2
+		Expect(exists("ns/plan/backup-1/a.txt")).To(BeFalse())
+		Expect(exists("ns/plan/backup-2.json")).To(BeTrue())
+
+		Expect(dst.EnsureSnapshotRetention(1)).Should(Succeed())
+		Expect(exists("ns/plan/backup-2.json")).To(BeFalse())
+		Expect(exists("ns/plan/backup-1/nested/b.txt")).To(BeFalse())
+		Expect(exists("ns/plan/backup-2/a.txt")).To(BeFalse())
+		Expect(exists("ns/plan/backup-3/a.txt")).To(BeTrue())
+	})
+})
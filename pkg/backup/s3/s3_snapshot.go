@@ -0,0 +1,157 @@
+/*
+Copyright 2020 Backup Operator Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package s3
+
+import (
+	"encoding/json"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/service/s3"
+)
+
+// S3ManifestSuffix is appended to the snapshot name to store its manifest
+const S3ManifestSuffix = ".json"
+
+// S3Manifest lists all objects of a snapshot created by S3PrefixSource
+type S3Manifest struct {
+	Snapshot string                     `json:"snapshot"`
+	Objects  map[string]S3ManifestEntry `json:"objects"`
+}
+
+// S3ManifestEntry references the snapshot storing an object, which differs
+// from the snapshot of the manifest, if the object was unchanged
+type S3ManifestEntry struct {
+	ETag     string `json:"etag"`
+	Snapshot string `json:"snapshot"`
+}
+
+// listSnapshots returns the names of all snapshots with a manifest sorted
+// from newest to oldest and all other objects of the destination
+func (s *S3Destination) listSnapshots() ([]string, []*s3.Object, error) {
+	prefix := s.Prefix + "/"
+	input := &s3.ListObjectsInput{
+		Bucket: &s.Bucket,
+		Prefix: &prefix,
+	}
+	snapshots := []string{}
+	objects := []*s3.Object{}
+	err := s.Client.ListObjectsPages(input,
+		func(page *s3.ListObjectsOutput, lastPage bool) bool {
+			for _, obj := range page.Contents {
+				name := strings.TrimPrefix(*obj.Key, prefix)
+				if !strings.Contains(name, "/") && strings.HasSuffix(name, S3ManifestSuffix) {
+					snapshots = append(snapshots, strings.TrimSuffix(name, S3ManifestSuffix))
+				} else {
+					objects = append(objects, obj)
+				}
+			}
+			return true
+		})
+	if err != nil {
+		return nil, nil, err
+	}
+	// snapshot names are expected to contain a timestamp
+	sort.Sort(sort.Reverse(sort.StringSlice(snapshots)))
+	return snapshots, objects, nil
+}
+
+func (s *S3Destination) loadManifest(snapshot string) (*S3Manifest, error) {
+	key := path.Join(s.Prefix, snapshot+S3ManifestSuffix)
+	params := &s3.GetObjectInput{
+		Bucket: &s.Bucket,
+		Key:    &key,
+	}
+	if s.EncryptionKey != nil {
+		params.SSECustomerAlgorithm = sseCustomerAlgorithm(s.EncryptionAlgorithm)
+		params.SSECustomerKey = s.EncryptionKey
+	}
+	res, err := s.Client.GetObject(params)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	var manifest S3Manifest
+	if err := json.NewDecoder(res.Body).Decode(&manifest); err != nil {
+		return nil, err
+	}
+	return &manifest, nil
+}
+
+// LoadLatestManifest returns the manifest of the latest snapshot or nil, if
+// no snapshot exists yet
+func (s *S3Destination) LoadLatestManifest() (*S3Manifest, error) {
+	snapshots, _, err := s.listSnapshots()
+	if err != nil || len(snapshots) == 0 {
+		return nil, err
+	}
+	return s.loadManifest(snapshots[0])
+}
+
+// EnsureSnapshotRetention removes all snapshots except the max latest ones.
+// Objects of removed snapshots are kept, if they are referenced by the
+// manifest of a remaining snapshot. Objects of incomplete snapshots older
+// than the remaining snapshots are removed as well. At least one snapshot is
+// always kept.
+func (s *S3Destination) EnsureSnapshotRetention(max int) error {
+	if max < 1 {
+		max = 1
+	}
+	snapshots, objects, err := s.listSnapshots()
+	if err != nil || len(snapshots) <= max {
+		return err
+	}
+	kept, obsolete := snapshots[:max], snapshots[max:]
+	referenced := map[string]bool{}
+	for _, snapshot := range kept {
+		manifest, err := s.loadManifest(snapshot)
+		if err != nil {
+			return err
+		}
+		for key, entry := range manifest.Objects {
+			referenced[path.Join(entry.Snapshot, key)] = true
+		}
+	}
+	oldest := kept[len(kept)-1]
+	prefix := s.Prefix + "/"
+	for _, obj := range objects {
+		name := strings.TrimPrefix(*obj.Key, prefix)
+		i := strings.Index(name, "/")
+		// keep objects of newer snapshots, which might still be in progress
+		if i < 0 || name[:i] >= oldest || referenced[name] {
+			continue
+		}
+		if err := s.deleteObject(*obj.Key); err != nil {
+			return err
+		}
+	}
+	for _, snapshot := range obsolete {
+		if err := s.deleteObject(path.Join(s.Prefix, snapshot+S3ManifestSuffix)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *S3Destination) deleteObject(key string) error {
+	_, err := s.Client.DeleteObject(&s3.DeleteObjectInput{
+		Bucket: &s.Bucket,
+		Key:    &key,
+	})
+	return err
+}
@@ -17,18 +17,12 @@ limitations under the License.
 package s3
 
 import (
-	"crypto/tls"
-	"fmt"
 	"io"
-	"net/http"
-	"time"
 
 	"github.com/kubism/backup-operator/pkg/backup"
 	"github.com/kubism/backup-operator/pkg/logger"
 
 	"github.com/aws/aws-sdk-go/aws"
-	"github.com/aws/aws-sdk-go/aws/awserr"
-	"github.com/aws/aws-sdk-go/aws/credentials"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/s3"
 	"github.com/aws/aws-sdk-go/service/s3/s3manager"
@@ -47,35 +41,12 @@ type S3SourceConf struct {
 }
 
 func NewS3Source(conf *S3SourceConf) (*S3Source, error) {
-	newSession, err := session.NewSession(&aws.Config{
-		Credentials:      credentials.NewStaticCredentials(conf.AccessKey, conf.SecretKey, ""),
-		Endpoint:         aws.String(conf.Endpoint),
-		Region:           aws.String("us-east-1"),
-		DisableSSL:       aws.Bool(conf.DisableSSL),
-		S3ForcePathStyle: aws.Bool(true),
-	})
+	newSession, client, err := newClient(conf.Endpoint, conf.AccessKey, conf.SecretKey, conf.DisableSSL, conf.InsecureSkipVerify)
 	if err != nil {
 		return nil, err
 	}
-
-	tr := &http.Transport{
-		TLSClientConfig: &tls.Config{InsecureSkipVerify: conf.InsecureSkipVerify},
-	}
-	cl := &http.Client{Transport: tr}
-	client := s3.New(newSession, aws.NewConfig().WithHTTPClient(cl))
-
-	// Create bucket, if not exists
-	_, err = client.CreateBucket(&s3.CreateBucketInput{
-		Bucket: aws.String(conf.Bucket),
-	})
-	if err != nil { // If bucket already exists ignore error
-		if aerr, ok := err.(awserr.Error); ok {
-			if aerr.Code() != s3.ErrCodeBucketAlreadyExists && aerr.Code() != s3.ErrCodeBucketAlreadyOwnedByYou {
-				return nil, err
-			}
-		} else {
-			return nil, err
-		}
+	if err := ensureBucket(client, conf.Bucket); err != nil {
+		return nil, err
 	}
 
 	return &S3Source{
@@ -119,28 +90,12 @@ func (s *S3Source) Stream(dst backup.Destination) (int64, error) {
 		params.SSECustomerKey = s.EncryptionKey
 	}
 
-	pr, pw := io.Pipe()
-	errc := make(chan error, 1)
-	defer close(errc)
-	go func() {
-		defer pw.Close()
+	return backup.StreamTo(dst, s.Key, func(w io.Writer) error {
 		log.Info("download starting", "bucket", s.Bucket, "key", s.Key)
-		numBytes, err := s.Downloader.Download(writerAtStub{pw}, params)
-		if err != nil {
-			errc <- err
-		}
+		numBytes, err := s.Downloader.Download(writerAtStub{w}, params)
 		log.Info("finished download", "numBytes", numBytes)
-	}()
-	written, dsterr := dst.Store(backup.Object{
-		ID:   s.Key,
-		Data: pr,
+		return err
 	})
-	select {
-	case srcerr := <-errc: // return src error if possible as well
-		return written, fmt.Errorf("dst error: %v; src error: %v", dsterr, srcerr)
-	case <-time.After(1 * time.Second):
-		return written, dsterr
-	}
 }
 
 type writerAtStub struct {
@@ -0,0 +1,112 @@
+/*
+Copyright 2020 Backup Operator Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sftp
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net"
+	"time"
+
+	"github.com/pkg/sftp"
+	"golang.org/x/crypto/ssh"
+)
+
+// DefaultPort of SSH servers
+const DefaultPort = 22
+
+const dialTimeout = 30 * time.Second
+
+type ClientConf struct {
+	Host string
+	// Port of the server, defaults to DefaultPort
+	Port     int
+	Username string
+	// Either a password or a private key file is required
+	Password       string
+	PrivateKeyFile string
+	// Passphrase of the private key, if it is encrypted
+	PrivateKeyPassphrase string
+	// Pinned public key of the server in authorized_keys format
+	HostKey string
+}
+
+// dial connects to the server and returns the SFTP client and a function
+// closing all connections
+func dial(conf *ClientConf) (*sftp.Client, func(), error) {
+	config, err := sshConfig(conf)
+	if err != nil {
+		return nil, nil, err
+	}
+	port := conf.Port
+	if port == 0 {
+		port = DefaultPort
+	}
+	addr := net.JoinHostPort(conf.Host, fmt.Sprintf("%d", port))
+	conn, err := ssh.Dial("tcp", addr, config)
+	if err != nil {
+		return nil, nil, err
+	}
+	client, err := sftp.NewClient(conn)
+	if err != nil {
+		conn.Close()
+		return nil, nil, err
+	}
+	return client, func() {
+		client.Close()
+		conn.Close()
+	}, nil
+}
+
+func sshConfig(conf *ClientConf) (*ssh.ClientConfig, error) {
+	if conf.HostKey == "" {
+		return nil, fmt.Errorf("host key is required")
+	}
+	hostKey, _, _, _, err := ssh.ParseAuthorizedKey([]byte(conf.HostKey))
+	if err != nil {
+		return nil, fmt.Errorf("invalid host key: %v", err)
+	}
+	auth := []ssh.AuthMethod{}
+	if conf.PrivateKeyFile != "" {
+		pem, err := ioutil.ReadFile(conf.PrivateKeyFile)
+		if err != nil {
+			return nil, err
+		}
+		var signer ssh.Signer
+		if conf.PrivateKeyPassphrase != "" {
+			signer, err = ssh.ParsePrivateKeyWithPassphrase(pem, []byte(conf.PrivateKeyPassphrase))
+		} else {
+			signer, err = ssh.ParsePrivateKey(pem)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid private key: %v", err)
+		}
+		auth = append(auth, ssh.PublicKeys(signer))
+	}
+	if conf.Password != "" {
+		auth = append(auth, ssh.Password(conf.Password))
+	}
+	if len(auth) == 0 {
+		return nil, fmt.Errorf("either a password or a private key is required")
+	}
+	return &ssh.ClientConfig{
+		User:            conf.Username,
+		Auth:            auth,
+		HostKeyCallback: ssh.FixedHostKey(hostKey),
+		Timeout:         dialTimeout,
+	}, nil
+}
@@ -0,0 +1,204 @@
+/*
+Copyright 2020 Backup Operator Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sftp
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/kubism/backup-operator/pkg/backup"
+	"github.com/kubism/backup-operator/pkg/logger"
+	"github.com/pkg/sftp"
+)
+
+// temporarySuffix of files, which are still uploading
+const temporarySuffix = ".tmp"
+
+type SFTPDestinationConf struct {
+	ClientConf
+	// Remote directory the prefix is relative to, defaults to the working
+	// directory of the user
+	Directory string
+	Prefix    string
+}
+
+func NewSFTPDestination(conf *SFTPDestinationConf) (*SFTPDestination, error) {
+	s := &SFTPDestination{
+		conf: conf.ClientConf,
+		Dir:  path.Join(conf.Directory, conf.Prefix),
+		log:  logger.WithName("sftpdst"),
+	}
+	// make sure connection and credentials are valid early
+	client, close, err := dial(&s.conf)
+	if err != nil {
+		return nil, err
+	}
+	defer close()
+	if err := client.MkdirAll(s.Dir); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+type SFTPDestination struct {
+	conf ClientConf
+	Dir  string
+	log  logger.Logger
+}
+
+// Store uploads the object to a temporary file, which is renamed after the
+// upload succeeded, so incomplete backups are never visible
+func (s *SFTPDestination) Store(obj backup.Object) (int64, error) {
+	name := path.Join(s.Dir, obj.ID)
+	tmpName := path.Join(path.Dir(name), fmt.Sprintf(".%s.%d%s", path.Base(name), time.Now().UnixNano(), temporarySuffix))
+	s.log.Info("upload starting", "host", s.conf.Host, "name", name)
+	client, close, err := dial(&s.conf)
+	if err != nil {
+		return 0, err
+	}
+	defer close()
+	if err := client.MkdirAll(path.Dir(name)); err != nil {
+		return 0, err
+	}
+	written, err := upload(client, tmpName, obj.Data)
+	if err != nil {
+		client.Remove(tmpName) // nolint:errcheck
+		return written, err
+	}
+	if _, ok := client.HasExtension("posix-rename@openssh.com"); ok {
+		err = client.PosixRename(tmpName, name)
+	} else {
+		// plain renames fail, if the target exists
+		if err := client.Remove(name); err != nil && !os.IsNotExist(err) {
+			client.Remove(tmpName) // nolint:errcheck
+			return written, err
+		}
+		err = client.Rename(tmpName, name)
+	}
+	if err != nil {
+		client.Remove(tmpName) // nolint:errcheck
+		return written, err
+	}
+	s.log.Info("upload successful", "numBytes", written)
+	return written, nil
+}
+
+func upload(client *sftp.Client, name string, data io.Reader) (int64, error) {
+	f, err := client.Create(name)
+	if err != nil {
+		return 0, err
+	}
+	written, err := io.Copy(f, data)
+	if err != nil {
+		f.Close()
+		return written, err
+	}
+	if _, ok := client.HasExtension("fsync@openssh.com"); ok {
+		if err := f.Sync(); err != nil {
+			f.Close()
+			return written, err
+		}
+	}
+	return written, f.Close()
+}
+
+// files returns all completely uploaded files below the directory of the
+// destination
+func (s *SFTPDestination) files(client *sftp.Client) ([]os.FileInfo, []string, error) {
+	infos := []os.FileInfo{}
+	names := []string{}
+	walker := client.Walk(s.Dir)
+	for walker.Step() {
+		if err := walker.Err(); err != nil {
+			return nil, nil, err
+		}
+		info := walker.Stat()
+		if info.IsDir() || strings.HasSuffix(info.Name(), temporarySuffix) {
+			continue
+		}
+		rel := strings.TrimPrefix(strings.TrimPrefix(walker.Path(), s.Dir), "/")
+		infos = append(infos, info)
+		names = append(names, rel)
+	}
+	return infos, names, nil
+}
+
+// EnsureRetention deletes all but the newest max files of the directory
+func (s *SFTPDestination) EnsureRetention(max int) error {
+	client, close, err := dial(&s.conf)
+	if err != nil {
+		return err
+	}
+	defer close()
+	infos, names, err := s.files(client)
+	if err != nil {
+		return err
+	}
+	if len(names) <= max {
+		return nil
+	}
+	idx := make([]int, len(names))
+	for i := range idx {
+		idx[i] = i
+	}
+	sort.Slice(idx, func(i, j int) bool {
+		ti, tj := infos[idx[i]].ModTime(), infos[idx[j]].ModTime()
+		if ti.Equal(tj) {
+			return names[idx[i]] > names[idx[j]] // names usually contain the time
+		}
+		return ti.After(tj)
+	})
+	for _, i := range idx[max:] {
+		if err := client.Remove(path.Join(s.Dir, names[i])); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ListObjectNames returns the names of all files of the destination
+// relative to its directory
+func (s *SFTPDestination) ListObjectNames() ([]string, error) {
+	client, close, err := dial(&s.conf)
+	if err != nil {
+		return nil, err
+	}
+	defer close()
+	_, names, err := s.files(client)
+	return names, err
+}
+
+// DeleteObjects deletes the files with the provided names relative to the
+// directory of the destination
+func (s *SFTPDestination) DeleteObjects(names []string) error {
+	client, close, err := dial(&s.conf)
+	if err != nil {
+		return err
+	}
+	defer close()
+	for _, name := range names {
+		if err := client.Remove(path.Join(s.Dir, name)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,126 @@
+/*
+Copyright 2020 Backup Operator Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sftp
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/kubism/backup-operator/pkg/backup"
+	"github.com/kubism/backup-operator/pkg/backup/mem"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+type failingReader struct{}
+
+func (failingReader) Read(p []byte) (int, error) {
+	return 0, fmt.Errorf("broken source")
+}
+
+var _ = Describe("SFTPDestination", func() {
+	It("should upload with password", func() {
+		data := []byte("temporarycontent")
+		src, _ := mem.NewBufferSource("password", data)
+		dst := newTestDestination("a", "ns/plan")
+		written, err := src.Stream(dst)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(written).To(Equal(int64(len(data))))
+		Expect(ioutil.ReadFile(filepath.Join(tmpDir, "a", "ns/plan/password"))).To(Equal(data))
+		Expect(dst.ListObjectNames()).To(Equal([]string{"password"}))
+	})
+	It("should upload with private key", func() {
+		conf := newTestConf("b", "ns/plan")
+		conf.Password = ""
+		conf.PrivateKeyFile = privateKeyFile
+		dst, err := NewSFTPDestination(conf)
+		Expect(err).ToNot(HaveOccurred())
+		src, _ := mem.NewBufferSource("key", []byte("temporarycontent"))
+		_, err = src.Stream(dst)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(filepath.Join(tmpDir, "b", "ns/plan/key")).To(BeARegularFile())
+	})
+	It("should reject unknown host keys", func() {
+		conf := newTestConf("c", "ns/plan")
+		conf.HostKey = "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIE0OOHZKcmSDwfSN/6lnXzlz0WCBmoUe4kPl/5pzmUc2"
+		_, err := NewSFTPDestination(conf)
+		Expect(err).To(HaveOccurred())
+	})
+	It("should require a host key", func() {
+		conf := newTestConf("c", "ns/plan")
+		conf.HostKey = ""
+		_, err := NewSFTPDestination(conf)
+		Expect(err).To(HaveOccurred())
+	})
+	It("should reject invalid passwords", func() {
+		conf := newTestConf("c", "ns/plan")
+		conf.Password = "invalid"
+		_, err := NewSFTPDestination(conf)
+		Expect(err).To(HaveOccurred())
+	})
+	It("should replace existing files", func() {
+		dst := newTestDestination("d", "ns/plan")
+		for _, content := range []string{"first", "second"} {
+			src, _ := mem.NewBufferSource("replaced", []byte(content))
+			_, err := src.Stream(dst)
+			Expect(err).ToNot(HaveOccurred())
+		}
+		Expect(ioutil.ReadFile(filepath.Join(tmpDir, "d", "ns/plan/replaced"))).To(Equal([]byte("second")))
+	})
+	It("should not leave incomplete files", func() {
+		dst := newTestDestination("e", "ns/plan")
+		_, err := dst.Store(backup.Object{
+			ID:   "incomplete",
+			Data: io.MultiReader(io.LimitReader(zeroReader{}, 1024), failingReader{}),
+		})
+		Expect(err).To(HaveOccurred())
+		Expect(ioutil.ReadDir(filepath.Join(tmpDir, "e", "ns/plan"))).To(BeEmpty())
+	})
+	It("should ensure retention", func() {
+		dst := newTestDestination("f", "ns/plan")
+		for _, id := range []string{"backup-1", "backup-2", "backup-3", "backup-4"} {
+			src, _ := mem.NewBufferSource(id, []byte(id))
+			_, err := src.Stream(dst)
+			Expect(err).ToNot(HaveOccurred())
+		}
+		Expect(dst.EnsureRetention(2)).To(Succeed())
+		Expect(dst.ListObjectNames()).To(ConsistOf("backup-3", "backup-4"))
+	})
+	It("should list and delete objects relative to the directory", func() {
+		dst := newTestDestination("g", "ns/plan")
+		for _, id := range []string{"backup-1", "oplog/1-2.bson.gz"} {
+			src, _ := mem.NewBufferSource(id, []byte(id))
+			_, err := src.Stream(dst)
+			Expect(err).ToNot(HaveOccurred())
+		}
+		Expect(dst.ListObjectNames()).To(ConsistOf("backup-1", "oplog/1-2.bson.gz"))
+		Expect(dst.DeleteObjects([]string{"oplog/1-2.bson.gz"})).To(Succeed())
+		Expect(dst.ListObjectNames()).To(Equal([]string{"backup-1"}))
+	})
+})
+
+type zeroReader struct{}
+
+func (zeroReader) Read(p []byte) (int, error) {
+	for i := range p {
+		p[i] = 0
+	}
+	return len(p), nil
+}
@@ -0,0 +1,173 @@
+/*
+Copyright 2020 Backup Operator Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sftp
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kubism/backup-operator/pkg/logger"
+	"github.com/onsi/ginkgo/reporters"
+	"github.com/pkg/sftp"
+	"golang.org/x/crypto/ssh"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var (
+	tmpDir         string
+	listener       net.Listener
+	host           string
+	port           int
+	hostKey        string
+	privateKeyFile string
+)
+
+const (
+	username = "backup"
+	password = "secret"
+)
+
+func TestSFTP(t *testing.T) {
+	RegisterFailHandler(Fail)
+	junitReporter := reporters.NewJUnitReporter("../../../reports/sftp-junit.xml")
+	RunSpecsWithDefaultAndCustomReporters(t, "SFTP", []Reporter{junitReporter})
+}
+
+var _ = BeforeSuite(func() {
+	var err error
+	log := logger.WithName("sftpsetup")
+	tmpDir, err = ioutil.TempDir("", "sftp")
+	Expect(err).ToNot(HaveOccurred())
+	By("generating keys")
+	_, hostPriv, err := ed25519.GenerateKey(rand.Reader)
+	Expect(err).ToNot(HaveOccurred())
+	hostSigner, err := ssh.NewSignerFromKey(hostPriv)
+	Expect(err).ToNot(HaveOccurred())
+	hostKey = string(ssh.MarshalAuthorizedKey(hostSigner.PublicKey()))
+	clientPub, clientPriv, err := ed25519.GenerateKey(rand.Reader)
+	Expect(err).ToNot(HaveOccurred())
+	der, err := x509.MarshalPKCS8PrivateKey(clientPriv)
+	Expect(err).ToNot(HaveOccurred())
+	privateKeyFile = filepath.Join(tmpDir, "id_ed25519")
+	Expect(ioutil.WriteFile(privateKeyFile, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), 0600)).To(Succeed())
+	authorizedKey, err := ssh.NewPublicKey(clientPub)
+	Expect(err).ToNot(HaveOccurred())
+	By("starting SFTP server")
+	config := &ssh.ServerConfig{
+		PasswordCallback: func(c ssh.ConnMetadata, pass []byte) (*ssh.Permissions, error) {
+			if c.User() == username && string(pass) == password {
+				return nil, nil
+			}
+			return nil, fmt.Errorf("password rejected for %q", c.User())
+		},
+		PublicKeyCallback: func(c ssh.ConnMetadata, key ssh.PublicKey) (*ssh.Permissions, error) {
+			if c.User() == username && bytes.Equal(key.Marshal(), authorizedKey.Marshal()) {
+				return nil, nil
+			}
+			return nil, fmt.Errorf("unknown public key for %q", c.User())
+		},
+	}
+	config.AddHostKey(hostSigner)
+	listener, err = net.Listen("tcp", "127.0.0.1:0")
+	Expect(err).ToNot(HaveOccurred())
+	host = "127.0.0.1"
+	port = listener.Addr().(*net.TCPAddr).Port
+	go serve(listener, config)
+	log.Info("SFTP server ready", "port", port)
+})
+
+var _ = AfterSuite(func() {
+	By("tearing down the test environment")
+	Expect(listener.Close()).To(Succeed())
+	Expect(os.RemoveAll(tmpDir)).To(Succeed())
+})
+
+// serve accepts connections and serves the local filesystem via the sftp
+// subsystem
+func serve(listener net.Listener, config *ssh.ServerConfig) {
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		go func() {
+			_, chans, reqs, err := ssh.NewServerConn(conn, config)
+			if err != nil {
+				return
+			}
+			go ssh.DiscardRequests(reqs)
+			for newChannel := range chans {
+				if newChannel.ChannelType() != "session" {
+					newChannel.Reject(ssh.UnknownChannelType, "unknown channel type") // nolint:errcheck
+					continue
+				}
+				channel, requests, err := newChannel.Accept()
+				if err != nil {
+					return
+				}
+				go func() {
+					for req := range requests {
+						ok := req.Type == "subsystem" && string(req.Payload[4:]) == "sftp"
+						req.Reply(ok, nil) // nolint:errcheck
+						if !ok {
+							continue
+						}
+						server, err := sftp.NewServer(channel)
+						if err != nil {
+							channel.Close()
+							return
+						}
+						server.Serve() // nolint:errcheck
+						server.Close()
+						return
+					}
+				}()
+			}
+		}()
+	}
+}
+
+func newTestConf(dir, prefix string) *SFTPDestinationConf {
+	return &SFTPDestinationConf{
+		ClientConf: ClientConf{
+			Host:     host,
+			Port:     port,
+			Username: username,
+			Password: password,
+			HostKey:  hostKey,
+		},
+		Directory: filepath.Join(tmpDir, dir),
+		Prefix:    prefix,
+	}
+}
+
+func newTestDestination(dir, prefix string) *SFTPDestination {
+	dst, err := NewSFTPDestination(newTestConf(dir, prefix))
+	Expect(err).ToNot(HaveOccurred())
+	return dst
+}
@@ -0,0 +1,68 @@
+/*
+Copyright 2020 Backup Operator Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backup
+
+import (
+	"fmt"
+	"io"
+	"os/exec"
+)
+
+// StreamTo stores everything written by produce as object with the provided
+// id. If produce fails, the upload is aborted with its error, so destinations
+// never store partial data. StreamTo returns after produce returned.
+func StreamTo(dst Destination, id string, produce func(w io.Writer) error) (int64, error) {
+	pr, pw := io.Pipe()
+	errc := make(chan error, 1)
+	go func() {
+		err := produce(pw)
+		pw.CloseWithError(err)
+		errc <- err
+	}()
+	written, dsterr := dst.Store(Object{
+		ID:   id,
+		Data: pr,
+	})
+	pr.Close() // make sure produce is not blocked, if destination failed early
+	srcerr := <-errc
+	switch {
+	case srcerr == nil:
+		return written, dsterr
+	case dsterr == nil || dsterr == srcerr: // upload was aborted by the source
+		return written, srcerr
+	default:
+		return written, fmt.Errorf("dst error: %v; src error: %v", dsterr, srcerr)
+	}
+}
+
+// RunCommand runs the command and copies its stdout to w. The command is
+// killed, if writing to w fails, so it is never blocked by a failed upload.
+func RunCommand(cmd *exec.Cmd, w io.Writer) error {
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return err
+	}
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	if _, err := io.Copy(w, stdout); err != nil {
+		_ = cmd.Process.Kill()
+		_ = cmd.Wait()
+		return err
+	}
+	return cmd.Wait()
+}
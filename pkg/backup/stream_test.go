@@ -0,0 +1,87 @@
+/*
+Copyright 2020 Backup Operator Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backup
+
+import (
+	"errors"
+	"io"
+	"io/ioutil"
+	"os/exec"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// readingDestination reads all data of an object and fails afterwards, if
+// an error is set
+type readingDestination struct {
+	data []byte
+	err  error
+}
+
+func (d *readingDestination) Store(obj Object) (int64, error) {
+	data, err := ioutil.ReadAll(obj.Data)
+	d.data = data
+	if err != nil {
+		return int64(len(data)), err
+	}
+	return int64(len(data)), d.err
+}
+
+var _ = Describe("StreamTo", func() {
+	It("should store the data without waiting", func() {
+		dst := &readingDestination{}
+		start := time.Now()
+		written, err := StreamTo(dst, "id", func(w io.Writer) error {
+			_, err := w.Write([]byte("data"))
+			return err
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(written).To(Equal(int64(4)))
+		Expect(dst.data).To(Equal([]byte("data")))
+		Expect(time.Since(start)).To(BeNumerically("<", 500*time.Millisecond))
+	})
+	It("should abort the upload with the error of the producer", func() {
+		failed := errors.New("failed")
+		_, err := StreamTo(&readingDestination{}, "id", func(w io.Writer) error {
+			_, _ = w.Write([]byte("partial"))
+			return failed
+		})
+		Expect(err).To(Equal(failed))
+	})
+	It("should wait for the producer, if the destination failed", func() {
+		failed := errors.New("failed")
+		done := false
+		_, err := StreamTo(&readingDestination{err: failed}, "id", func(w io.Writer) error {
+			time.Sleep(100 * time.Millisecond)
+			done = true
+			return nil
+		})
+		Expect(err).To(Equal(failed))
+		Expect(done).To(BeTrue())
+	})
+})
+
+var _ = Describe("RunCommand", func() {
+	It("should kill the command, if writing fails", func() {
+		cmd := exec.Command("sh", "-c", "yes")
+		pr, pw := io.Pipe()
+		pr.Close()
+		Expect(RunCommand(cmd, pw)).To(Equal(io.ErrClosedPipe))
+	})
+})
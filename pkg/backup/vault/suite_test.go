@@ -0,0 +1,92 @@
+/*
+Copyright 2020 Backup Operator Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vault
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/kubism/backup-operator/pkg/logger"
+	"github.com/kubism/backup-operator/pkg/testutil"
+	"github.com/onsi/ginkgo/reporters"
+	"github.com/ory/dockertest/v3"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var (
+	pool        *dockertest.Pool
+	srcResource *dockertest.Resource
+	dstResource *dockertest.Resource
+	srcAddress  string
+	dstAddress  string
+	srcToken    string
+	srcKey      string
+	dstToken    string
+)
+
+func TestVault(t *testing.T) {
+	RegisterFailHandler(Fail)
+	junitReporter := reporters.NewJUnitReporter("../../../reports/vault-junit.xml")
+	RunSpecsWithDefaultAndCustomReporters(t, "Vault", []Reporter{junitReporter})
+}
+
+var _ = BeforeSuite(func(done Done) {
+	var err error
+	log := logger.WithName("vaultsetup")
+	By("bootstrapping both vaults")
+	pool, err = dockertest.NewPool("")
+	Expect(err).ToNot(HaveOccurred())
+	options := &dockertest.RunOptions{
+		Repository: "vault",
+		Tag:        "1.9.3",
+		Cmd:        []string{"server"},
+		Env:        []string{fmt.Sprintf("VAULT_LOCAL_CONFIG=%s", testutil.VaultRaftConfig)},
+	}
+	log.Info("spawn src vault container")
+	srcResource, err = pool.RunWithOptions(options)
+	Expect(err).ToNot(HaveOccurred())
+	srcAddress = fmt.Sprintf("http://localhost:%s", srcResource.GetPort("8200/tcp"))
+	log.Info("spawn dst vault container")
+	dstResource, err = pool.RunWithOptions(options)
+	Expect(err).ToNot(HaveOccurred())
+	dstAddress = fmt.Sprintf("http://localhost:%s", dstResource.GetPort("8200/tcp"))
+	log.Info("initialize src vault", "address", srcAddress)
+	err = testutil.WaitForVault(pool, srcAddress)
+	Expect(err).ToNot(HaveOccurred())
+	srcToken, srcKey, err = testutil.InitVault(pool, srcAddress)
+	Expect(err).ToNot(HaveOccurred())
+	log.Info("insert test data", "address", srcAddress)
+	err = testutil.InsertVaultTestData(srcAddress, srcToken)
+	Expect(err).ToNot(HaveOccurred())
+	log.Info("initialize dst vault", "address", dstAddress)
+	err = testutil.WaitForVault(pool, dstAddress)
+	Expect(err).ToNot(HaveOccurred())
+	dstToken, _, err = testutil.InitVault(pool, dstAddress)
+	Expect(err).ToNot(HaveOccurred())
+	log.Info("vaults ready")
+	close(done)
+}, 60)
+
+var _ = AfterSuite(func() {
+	By("tearing down the test environment")
+	err1 := pool.Purge(srcResource)
+	err2 := pool.Purge(dstResource)
+	Expect(err1).ToNot(HaveOccurred())
+	Expect(err2).ToNot(HaveOccurred())
+})
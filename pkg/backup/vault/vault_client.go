@@ -0,0 +1,153 @@
+/*
+Copyright 2020 Backup Operator Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vault
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+)
+
+const (
+	DefaultKubernetesMountPath = "kubernetes"
+	DefaultKubernetesTokenPath = "/var/run/secrets/kubernetes.io/serviceaccount/token"
+)
+
+type VaultConf struct {
+	// Address of vault including the scheme, e.g. https://vault:8200
+	Address string
+	// Token used to authenticate. If empty, the kubernetes auth method is
+	// used instead.
+	Token string
+	// Role to login with using the kubernetes auth method
+	KubernetesRole string
+	// Path the kubernetes auth method is mounted at, defaults to kubernetes
+	KubernetesMountPath string
+	// Path of the service account token, defaults to the token mounted into
+	// every pod
+	KubernetesTokenPath string
+	// Path of the PEM encoded CA certificate to verify vault with
+	CACert string
+}
+
+type client struct {
+	Address string
+	Token   string
+	HTTP    *http.Client
+}
+
+// newClient creates a client for the HTTP API of vault and logs in using the
+// kubernetes auth method, if no token is provided.
+func newClient(conf *VaultConf) (*client, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if conf.CACert != "" {
+		pem, err := ioutil.ReadFile(conf.CACert)
+		if err != nil {
+			return nil, err
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in %s", conf.CACert)
+		}
+		transport.TLSClientConfig = &tls.Config{RootCAs: pool}
+	}
+	c := &client{
+		Address: strings.TrimSuffix(conf.Address, "/"),
+		Token:   conf.Token,
+		HTTP:    &http.Client{Transport: transport},
+	}
+	if c.Token == "" {
+		if conf.KubernetesRole == "" {
+			return nil, fmt.Errorf("either token or kubernetes role is required")
+		}
+		if err := c.loginKubernetes(conf); err != nil {
+			return nil, err
+		}
+	}
+	return c, nil
+}
+
+func (c *client) loginKubernetes(conf *VaultConf) error {
+	mountPath := conf.KubernetesMountPath
+	if mountPath == "" {
+		mountPath = DefaultKubernetesMountPath
+	}
+	tokenPath := conf.KubernetesTokenPath
+	if tokenPath == "" {
+		tokenPath = DefaultKubernetesTokenPath
+	}
+	jwt, err := ioutil.ReadFile(tokenPath)
+	if err != nil {
+		return err
+	}
+	body, err := json.Marshal(map[string]string{
+		"role": conf.KubernetesRole,
+		"jwt":  strings.TrimSpace(string(jwt)),
+	})
+	if err != nil {
+		return err
+	}
+	res, err := c.do(http.MethodPost, fmt.Sprintf("auth/%s/login", strings.Trim(mountPath, "/")), bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	var login struct {
+		Auth struct {
+			ClientToken string `json:"client_token"`
+		} `json:"auth"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&login); err != nil {
+		return err
+	}
+	if login.Auth.ClientToken == "" {
+		return fmt.Errorf("kubernetes login did not return a token")
+	}
+	c.Token = login.Auth.ClientToken
+	return nil
+}
+
+// do sends a request to the API of vault and returns an error, if the
+// response does not indicate success.
+func (c *client) do(method, path string, body io.Reader) (*http.Response, error) {
+	req, err := http.NewRequest(method, fmt.Sprintf("%s/v1/%s", c.Address, path), body)
+	if err != nil {
+		return nil, err
+	}
+	if c.Token != "" {
+		req.Header.Set("X-Vault-Token", c.Token)
+	}
+	res, err := c.HTTP.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		defer res.Body.Close()
+		var errs struct {
+			Errors []string `json:"errors"`
+		}
+		_ = json.NewDecoder(res.Body).Decode(&errs)
+		return nil, fmt.Errorf("vault returned %s for %s %s: %s", res.Status, method, path, strings.Join(errs.Errors, "; "))
+	}
+	return res, nil
+}
@@ -0,0 +1,64 @@
+/*
+Copyright 2020 Backup Operator Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vault
+
+import (
+	"net/http"
+
+	"github.com/kubism/backup-operator/pkg/backup"
+	"github.com/kubism/backup-operator/pkg/logger"
+)
+
+type vaultDestination struct {
+	Client *client
+	Force  bool
+	log    logger.Logger
+}
+
+// NewVaultDestination creates a destination restoring raft snapshots. If
+// force is set, snapshots of other clusters are restored as well, which
+// requires the unseal keys of the original cluster afterwards.
+func NewVaultDestination(conf *VaultConf, force bool) (backup.Destination, error) {
+	client, err := newClient(conf)
+	if err != nil {
+		return nil, err
+	}
+
+	return &vaultDestination{
+		Client: client,
+		Force:  force,
+		log:    logger.WithName("vaultdst"),
+	}, nil
+}
+
+func (s *vaultDestination) Store(obj backup.Object) (int64, error) {
+	log := s.log
+
+	path := "sys/storage/raft/snapshot"
+	if s.Force {
+		path = "sys/storage/raft/snapshot-force"
+	}
+	log.Info("restore starting", "force", s.Force)
+	res, err := s.Client.do(http.MethodPost, path, obj.Data)
+	if err != nil {
+		log.Error(err, "Failed to write snapshot to vault")
+		return 0, err
+	}
+	res.Body.Close()
+	log.Info("restore finished")
+	return 0, nil // NOTE: written bytes not supported
+}
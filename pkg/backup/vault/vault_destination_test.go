@@ -0,0 +1,62 @@
+/*
+Copyright 2020 Backup Operator Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vault
+
+import (
+	"github.com/kubism/backup-operator/pkg/testutil"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("VaultDestination", func() {
+	It("should refuse snapshot of other cluster without force", func() {
+		src, err := NewVaultSource(&VaultConf{
+			Address: srcAddress,
+			Token:   srcToken,
+		}, "backup.snap")
+		Expect(err).ToNot(HaveOccurred())
+		dst, err := NewVaultDestination(&VaultConf{
+			Address: dstAddress,
+			Token:   dstToken,
+		}, false)
+		Expect(err).ToNot(HaveOccurred())
+		_, err = src.Stream(dst)
+		Expect(err).To(HaveOccurred())
+	})
+	It("should restore snapshot with force", func() {
+		src, err := NewVaultSource(&VaultConf{
+			Address: srcAddress,
+			Token:   srcToken,
+		}, "backup.snap")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(src).ToNot(BeNil())
+		dst, err := NewVaultDestination(&VaultConf{
+			Address: dstAddress,
+			Token:   dstToken,
+		}, true)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(dst).ToNot(BeNil())
+		_, err = src.Stream(dst)
+		Expect(err).ToNot(HaveOccurred())
+		// restored vault uses the keys and tokens of the source cluster
+		err = testutil.UnsealVault(pool, dstAddress, srcKey)
+		Expect(err).ToNot(HaveOccurred())
+		err = testutil.FindVaultTestData(dstAddress, srcToken)
+		Expect(err).ToNot(HaveOccurred())
+	})
+})
@@ -0,0 +1,62 @@
+/*
+Copyright 2020 Backup Operator Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vault
+
+import (
+	"io"
+	"net/http"
+
+	"github.com/kubism/backup-operator/pkg/backup"
+	"github.com/kubism/backup-operator/pkg/logger"
+)
+
+type vaultSource struct {
+	SnapName string
+	Client   *client
+	log      logger.Logger
+}
+
+func NewVaultSource(conf *VaultConf, snapName string) (backup.Source, error) {
+	client, err := newClient(conf)
+	if err != nil {
+		return nil, err
+	}
+
+	return &vaultSource{
+		SnapName: snapName,
+		Client:   client,
+		log:      logger.WithName("vaultsrc"),
+	}, nil
+}
+
+func (s *vaultSource) Stream(dst backup.Destination) (int64, error) {
+	log := s.log
+
+	res, err := s.Client.do(http.MethodGet, "sys/storage/raft/snapshot", nil)
+	if err != nil {
+		log.Error(err, "Could not get snapshot from vault")
+		return 0, err
+	}
+	reader := res.Body
+	defer reader.Close()
+	return backup.StreamTo(dst, s.SnapName, func(w io.Writer) error {
+		log.Info("starting dump")
+		numBytes, err := io.Copy(w, reader)
+		log.Info("finished dump", "numBytes", numBytes)
+		return err
+	})
+}
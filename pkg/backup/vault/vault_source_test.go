@@ -0,0 +1,91 @@
+/*
+Copyright 2020 Backup Operator Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vault
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+
+	"github.com/kubism/backup-operator/pkg/backup/fs"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("VaultSource", func() {
+	It("should dump to file", func() {
+		src, err := NewVaultSource(&VaultConf{
+			Address: srcAddress,
+			Token:   srcToken,
+		}, "backup.snap")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(src).ToNot(BeNil())
+		dir, err := ioutil.TempDir("", "vaultsrc")
+		Expect(err).ToNot(HaveOccurred())
+		defer os.RemoveAll(dir)
+		fp := filepath.Join(dir, "backup.snap")
+		dst, err := fs.NewDirDestination(dir)
+		Expect(err).ToNot(HaveOccurred())
+		written, err := src.Stream(dst)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(written).To(BeNumerically(">", 0))
+		Expect(fp).Should(BeAnExistingFile())
+	})
+	It("should fail without valid token", func() {
+		src, err := NewVaultSource(&VaultConf{
+			Address: srcAddress,
+			Token:   "invalid",
+		}, "backup.snap")
+		Expect(err).ToNot(HaveOccurred())
+		dir, err := ioutil.TempDir("", "vaultsrc")
+		Expect(err).ToNot(HaveOccurred())
+		defer os.RemoveAll(dir)
+		dst, err := fs.NewDirDestination(dir)
+		Expect(err).ToNot(HaveOccurred())
+		_, err = src.Stream(dst)
+		Expect(err).To(HaveOccurred())
+	})
+	It("should require token or kubernetes role", func() {
+		_, err := NewVaultSource(&VaultConf{
+			Address: srcAddress,
+		}, "backup.snap")
+		Expect(err).To(HaveOccurred())
+	})
+	It("should not store truncated snapshots", func() {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Length", "1024")
+			w.Write([]byte("partial")) // nolint:errcheck
+		}))
+		defer server.Close()
+		src, err := NewVaultSource(&VaultConf{
+			Address: server.URL,
+			Token:   "token",
+		}, "backup.snap")
+		Expect(err).ToNot(HaveOccurred())
+		dir, err := ioutil.TempDir("", "vaultsrc")
+		Expect(err).ToNot(HaveOccurred())
+		defer os.RemoveAll(dir)
+		dst, err := fs.NewDirDestination(dir)
+		Expect(err).ToNot(HaveOccurred())
+		_, err = src.Stream(dst)
+		Expect(err).To(HaveOccurred())
+		Expect(ioutil.ReadDir(dir)).To(BeEmpty())
+	})
+})
@@ -0,0 +1,184 @@
+/*
+Copyright 2020 Backup Operator Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webdav
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/onsi/ginkgo/reporters"
+	"golang.org/x/net/webdav"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var (
+	server   *httptest.Server
+	filesFS  webdav.FileSystem
+	chunksMu sync.Mutex
+	chunks   map[string]int
+)
+
+const (
+	username = "backup"
+	password = "secret"
+	token    = "token"
+)
+
+func TestWebDAV(t *testing.T) {
+	RegisterFailHandler(Fail)
+	junitReporter := reporters.NewJUnitReporter("../../../reports/webdav-junit.xml")
+	RunSpecsWithDefaultAndCustomReporters(t, "WebDAV", []Reporter{junitReporter})
+}
+
+var _ = BeforeSuite(func() {
+	By("starting WebDAV server")
+	filesFS = webdav.NewMemFS()
+	uploadsFS := webdav.NewMemFS()
+	chunks = map[string]int{}
+	files := &webdav.Handler{
+		Prefix:     "/files",
+		FileSystem: filesFS,
+		LockSystem: webdav.NewMemLS(),
+	}
+	uploads := &webdav.Handler{
+		Prefix:     "/uploads",
+		FileSystem: uploadsFS,
+		LockSystem: webdav.NewMemLS(),
+	}
+	mux := http.NewServeMux()
+	mux.Handle("/files/", files)
+	mux.HandleFunc("/uploads/", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Destination") == "" && r.Method != http.MethodDelete {
+			http.Error(w, "destination header required", http.StatusBadRequest)
+			return
+		}
+		if r.Method == "MOVE" && path.Base(r.URL.Path) == ".file" {
+			assembleChunks(w, r, uploadsFS)
+			return
+		}
+		if r.Method == http.MethodPut {
+			chunksMu.Lock()
+			chunks[r.Header.Get("Destination")]++
+			chunksMu.Unlock()
+		}
+		uploads.ServeHTTP(w, r)
+	})
+	server = httptest.NewServer(authenticated(mux))
+})
+
+var _ = AfterSuite(func() {
+	By("tearing down the test environment")
+	server.Close()
+})
+
+// authenticated accepts either basic or bearer authentication
+func authenticated(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, pass, ok := r.BasicAuth()
+		if !(ok && user == username && pass == password) && r.Header.Get("Authorization") != "Bearer "+token {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// assembleChunks emulates the final MOVE of chunked uploads of Nextcloud
+func assembleChunks(w http.ResponseWriter, r *http.Request, uploadsFS webdav.FileSystem) {
+	ctx := context.Background()
+	dir := strings.TrimPrefix(path.Dir(r.URL.Path), "/uploads")
+	dst, err := url.Parse(r.Header.Get("Destination"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	d, err := uploadsFS.OpenFile(ctx, dir, os.O_RDONLY, 0)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	infos, err := d.Readdir(-1)
+	d.Close()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	sort.Slice(infos, func(i, j int) bool { return infos[i].Name() < infos[j].Name() })
+	f, err := filesFS.OpenFile(ctx, strings.TrimPrefix(dst.Path, "/files"), os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	}
+	defer f.Close()
+	for _, info := range infos {
+		chunk, err := uploadsFS.OpenFile(ctx, path.Join(dir, info.Name()), os.O_RDONLY, 0)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		_, err = io.Copy(f, chunk)
+		chunk.Close()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+	if err := uploadsFS.RemoveAll(ctx, dir); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusCreated)
+}
+
+func newTestConf(chunked bool) ClientConf {
+	conf := ClientConf{
+		URL:      server.URL + "/files",
+		Username: username,
+		Password: password,
+	}
+	if chunked {
+		conf.UploadsURL = server.URL + "/uploads"
+	}
+	return conf
+}
+
+func newTestDestination(prefix string, chunked bool) *WebDAVDestination {
+	dst, err := NewWebDAVDestination(&WebDAVDestinationConf{
+		ClientConf: newTestConf(chunked),
+		Prefix:     prefix,
+		ChunkSize:  minChunkSize,
+	})
+	Expect(err).ToNot(HaveOccurred())
+	return dst
+}
+
+func uploadedChunks(dst *WebDAVDestination, name string) int {
+	chunksMu.Lock()
+	defer chunksMu.Unlock()
+	return chunks[dst.client.url(dst.client.BaseURL, name)]
+}
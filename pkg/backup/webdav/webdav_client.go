@@ -0,0 +1,371 @@
+/*
+Copyright 2020 Backup Operator Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webdav
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kubism/backup-operator/pkg/backup"
+)
+
+// maxErrorBodySize limits the response body included in errors
+const maxErrorBodySize = 1024
+
+const propfindBody = `<?xml version="1.0" encoding="utf-8"?>
+<d:propfind xmlns:d="DAV:"><d:prop><d:resourcetype/><d:getlastmodified/></d:prop></d:propfind>`
+
+type ClientConf struct {
+	// URL of the collection files are stored relative to, e.g.
+	// https://cloud.example.com/remote.php/dav/files/<user>
+	URL string
+	// URL of the upload collection of Nextcloud, e.g.
+	// https://cloud.example.com/remote.php/dav/uploads/<user>. If empty,
+	// files are uploaded with a single request.
+	UploadsURL string
+	// Username and password used for basic authentication
+	Username string
+	Password string
+	// Token used for bearer authentication
+	BearerToken string
+}
+
+type client struct {
+	Conf    *ClientConf
+	BaseURL *url.URL
+	HTTP    *http.Client
+}
+
+// resource is a file or collection returned by PROPFIND
+type resource struct {
+	// Path relative to the URL of the client
+	Name         string
+	Collection   bool
+	LastModified time.Time
+}
+
+func newClient(conf *ClientConf) (*client, error) {
+	if conf.URL == "" {
+		return nil, fmt.Errorf("url is required")
+	}
+	baseURL, err := url.Parse(strings.TrimSuffix(conf.URL, "/") + "/")
+	if err != nil {
+		return nil, err
+	}
+	return &client{
+		Conf:    conf,
+		BaseURL: baseURL,
+		HTTP:    &http.Client{},
+	}, nil
+}
+
+// url returns the escaped URL of the path relative to the base URL
+func (c *client) url(base *url.URL, name string) string {
+	segments := strings.Split(strings.Trim(name, "/"), "/")
+	for i := range segments {
+		segments[i] = url.PathEscape(segments[i])
+	}
+	return base.String() + strings.Join(segments, "/")
+}
+
+func (c *client) newRequest(method, u string, body io.Reader) (*http.Request, error) {
+	req, err := http.NewRequest(method, u, body)
+	if err != nil {
+		return nil, err
+	}
+	if c.Conf.BearerToken != "" {
+		req.Header.Set("Authorization", "Bearer "+c.Conf.BearerToken)
+	} else if c.Conf.Username != "" || c.Conf.Password != "" {
+		req.SetBasicAuth(c.Conf.Username, c.Conf.Password)
+	}
+	return req, nil
+}
+
+// do sends the request and returns an error, if the response does not have
+// any of the expected status codes
+func (c *client) do(req *http.Request, expected ...int) (*http.Response, error) {
+	res, err := c.HTTP.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	for _, code := range expected {
+		if res.StatusCode == code {
+			return res, nil
+		}
+	}
+	defer res.Body.Close()
+	msg, _ := ioutil.ReadAll(io.LimitReader(res.Body, maxErrorBodySize))
+	return nil, &statusError{
+		Code: res.StatusCode,
+		Msg:  fmt.Sprintf("%s %s returned %s: %s", req.Method, req.URL.Path, res.Status, strings.TrimSpace(string(msg))),
+	}
+}
+
+// send is a shorthand of do for requests without relevant response body
+func (c *client) send(method, u string, body io.Reader, headers map[string]string, expected ...int) error {
+	req, err := c.newRequest(method, u, body)
+	if err != nil {
+		return err
+	}
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+	res, err := c.do(req, expected...)
+	if err != nil {
+		return err
+	}
+	return res.Body.Close()
+}
+
+type statusError struct {
+	Code int
+	Msg  string
+}
+
+func (e *statusError) Error() string {
+	return e.Msg
+}
+
+func isNotFound(err error) bool {
+	serr, ok := err.(*statusError)
+	return ok && serr.Code == http.StatusNotFound
+}
+
+// mkcolAll creates the collection and all parents, which do not exist
+func (c *client) mkcolAll(name string) error {
+	current := ""
+	for _, segment := range strings.Split(strings.Trim(name, "/"), "/") {
+		if segment == "" || segment == "." {
+			continue
+		}
+		current = path.Join(current, segment)
+		err := c.send("MKCOL", c.url(c.BaseURL, current), nil, nil, http.StatusCreated)
+		if serr, ok := err.(*statusError); ok && serr.Code == http.StatusMethodNotAllowed {
+			continue // already exists
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// upload stores the data either with a single request or with chunks, if
+// the uploads URL is configured
+func (c *client) upload(name string, data io.Reader, chunkSize int) (int64, error) {
+	if err := c.mkcolAll(path.Dir(name)); err != nil {
+		return 0, err
+	}
+	if c.Conf.UploadsURL == "" {
+		return c.uploadSingle(name, data)
+	}
+	return c.uploadChunked(name, data, chunkSize)
+}
+
+// uploadSingle uploads the data to a temporary file next to the final name,
+// which is moved onto the final name afterwards, so failed uploads never
+// replace the file with partial data
+func (c *client) uploadSingle(name string, data io.Reader) (int64, error) {
+	id, err := newUploadID()
+	if err != nil {
+		return 0, err
+	}
+	tmpURL := c.url(c.BaseURL, path.Join(path.Dir(name), ".backup-operator-"+id))
+	counter := &backup.CountingReader{Reader: data}
+	if err := c.send(http.MethodPut, tmpURL, counter, nil, http.StatusCreated, http.StatusNoContent); err != nil {
+		c.abort(tmpURL)
+		return counter.N, err
+	}
+	headers := map[string]string{"Destination": c.url(c.BaseURL, name), "Overwrite": "T"}
+	if err := c.send("MOVE", tmpURL, nil, headers, http.StatusCreated, http.StatusNoContent); err != nil {
+		c.abort(tmpURL)
+		return counter.N, err
+	}
+	return counter.N, nil
+}
+
+// uploadChunked uploads the data using chunking v2 of Nextcloud, see
+// https://docs.nextcloud.com/server/latest/developer_manual/client_apis/WebDAV/chunking.html
+func (c *client) uploadChunked(name string, data io.Reader, chunkSize int) (int64, error) {
+	uploadsURL, err := url.Parse(strings.TrimSuffix(c.Conf.UploadsURL, "/") + "/")
+	if err != nil {
+		return 0, err
+	}
+	id, err := newUploadID()
+	if err != nil {
+		return 0, err
+	}
+	uploadURL := c.url(uploadsURL, "backup-operator-"+id)
+	headers := map[string]string{"Destination": c.url(c.BaseURL, name)}
+	if err := c.send("MKCOL", uploadURL, nil, headers, http.StatusCreated); err != nil {
+		return 0, err
+	}
+	buf := make([]byte, chunkSize)
+	var written int64
+	for chunk := 1; ; chunk++ {
+		n, err := io.ReadFull(data, buf)
+		if n > 0 {
+			if chunk > maxChunks {
+				c.abort(uploadURL)
+				return written, fmt.Errorf("more than %d chunks required, increase the chunk size", maxChunks)
+			}
+			// chunks are sorted by their name, so the names are padded
+			if err := c.send(http.MethodPut, fmt.Sprintf("%s/%05d", uploadURL, chunk), bytes.NewReader(buf[:n]), headers, http.StatusCreated, http.StatusNoContent); err != nil {
+				c.abort(uploadURL)
+				return written, err
+			}
+			written += int64(n)
+		}
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			break
+		}
+		if err != nil {
+			c.abort(uploadURL)
+			return written, err
+		}
+	}
+	headers["OC-Total-Length"] = strconv.FormatInt(written, 10)
+	if err := c.send("MOVE", uploadURL+"/.file", nil, headers, http.StatusCreated, http.StatusNoContent); err != nil {
+		c.abort(uploadURL)
+		return written, err
+	}
+	return written, nil
+}
+
+// abort removes the temporary file or chunks of an upload, which failed
+func (c *client) abort(uploadURL string) {
+	c.send(http.MethodDelete, uploadURL, nil, nil, http.StatusNoContent) // nolint:errcheck
+}
+
+// newUploadID returns a random id used to name temporary uploads
+func newUploadID() (string, error) {
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(id), nil
+}
+
+// download returns the content of the file
+func (c *client) download(name string) (io.ReadCloser, error) {
+	req, err := c.newRequest(http.MethodGet, c.url(c.BaseURL, name), nil)
+	if err != nil {
+		return nil, err
+	}
+	res, err := c.do(req, http.StatusOK)
+	if err != nil {
+		return nil, err
+	}
+	return res.Body, nil
+}
+
+// list returns all files below the collection. As many servers do not allow
+// PROPFIND with infinite depth, collections are listed one by one.
+func (c *client) list(name string) ([]resource, error) {
+	resources, err := c.propfind(name)
+	if isNotFound(err) {
+		return []resource{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	files := []resource{}
+	for _, r := range resources {
+		if !r.Collection {
+			files = append(files, r)
+			continue
+		}
+		children, err := c.list(r.Name)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, children...)
+	}
+	return files, nil
+}
+
+// propfind returns the members of the collection
+func (c *client) propfind(name string) ([]resource, error) {
+	req, err := c.newRequest("PROPFIND", c.url(c.BaseURL, name)+"/", strings.NewReader(propfindBody))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Depth", "1")
+	req.Header.Set("Content-Type", "application/xml; charset=utf-8")
+	res, err := c.do(req, http.StatusMultiStatus)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	var ms struct {
+		Responses []struct {
+			Href     string `xml:"href"`
+			Propstat []struct {
+				Prop struct {
+					ResourceType struct {
+						Collection *struct{} `xml:"collection"`
+					} `xml:"resourcetype"`
+					LastModified string `xml:"getlastmodified"`
+				} `xml:"prop"`
+				Status string `xml:"status"`
+			} `xml:"propstat"`
+		} `xml:"response"`
+	}
+	if err := xml.NewDecoder(res.Body).Decode(&ms); err != nil {
+		return nil, err
+	}
+	self := strings.Trim(name, "/")
+	resources := []resource{}
+	for _, r := range ms.Responses {
+		href, err := url.Parse(r.Href)
+		if err != nil {
+			return nil, err
+		}
+		rel := strings.Trim(strings.TrimPrefix(c.BaseURL.ResolveReference(href).Path, c.BaseURL.Path), "/")
+		if rel == self {
+			continue // the collection itself
+		}
+		resource := resource{Name: rel}
+		for _, ps := range r.Propstat {
+			if !strings.Contains(ps.Status, " 200 ") {
+				continue
+			}
+			resource.Collection = resource.Collection || ps.Prop.ResourceType.Collection != nil
+			if ps.Prop.LastModified != "" {
+				resource.LastModified, _ = http.ParseTime(ps.Prop.LastModified)
+			}
+		}
+		resources = append(resources, resource)
+	}
+	return resources, nil
+}
+
+// delete removes the file or collection
+func (c *client) delete(name string) error {
+	return c.send(http.MethodDelete, c.url(c.BaseURL, name), nil, nil, http.StatusNoContent, http.StatusOK)
+}
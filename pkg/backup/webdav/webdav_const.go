@@ -0,0 +1,27 @@
+/*
+Copyright 2020 Backup Operator Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webdav
+
+// DefaultChunkSize of chunked uploads
+const DefaultChunkSize = 10 * 1024 * 1024
+
+// Nextcloud requires chunks of at least 5 MiB except for the last one and
+// at most 10000 chunks per upload
+const (
+	minChunkSize = 5 * 1024 * 1024
+	maxChunks    = 10000
+)
@@ -0,0 +1,124 @@
+/*
+Copyright 2020 Backup Operator Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webdav
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/kubism/backup-operator/pkg/backup"
+	"github.com/kubism/backup-operator/pkg/logger"
+)
+
+type WebDAVDestinationConf struct {
+	ClientConf
+	Prefix string
+	// Size of the chunks of chunked uploads, defaults to DefaultChunkSize
+	ChunkSize int
+}
+
+func NewWebDAVDestination(conf *WebDAVDestinationConf) (*WebDAVDestination, error) {
+	chunkSize := conf.ChunkSize
+	if chunkSize == 0 {
+		chunkSize = DefaultChunkSize
+	}
+	if chunkSize < minChunkSize {
+		return nil, fmt.Errorf("chunk size has to be at least %d", minChunkSize)
+	}
+	client, err := newClient(&conf.ClientConf)
+	if err != nil {
+		return nil, err
+	}
+	if err := client.mkcolAll(conf.Prefix); err != nil {
+		return nil, err
+	}
+	return &WebDAVDestination{
+		client:    client,
+		Prefix:    conf.Prefix,
+		ChunkSize: chunkSize,
+		log:       logger.WithName("webdavdst"),
+	}, nil
+}
+
+type WebDAVDestination struct {
+	client    *client
+	Prefix    string
+	ChunkSize int
+	log       logger.Logger
+}
+
+func (w *WebDAVDestination) Store(obj backup.Object) (int64, error) {
+	name := path.Join(w.Prefix, obj.ID)
+	w.log.Info("upload starting", "url", w.client.Conf.URL, "name", name)
+	written, err := w.client.upload(name, obj.Data, w.ChunkSize)
+	if err != nil {
+		return written, err
+	}
+	w.log.Info("upload successful", "numBytes", written)
+	return written, nil
+}
+
+// EnsureRetention deletes all but the newest max files below the prefix
+func (w *WebDAVDestination) EnsureRetention(max int) error {
+	files, err := w.client.list(w.Prefix)
+	if err != nil {
+		return err
+	}
+	if len(files) <= max {
+		return nil
+	}
+	sort.Slice(files, func(i, j int) bool {
+		if files[i].LastModified.Equal(files[j].LastModified) {
+			return files[i].Name > files[j].Name // names usually contain the time
+		}
+		return files[i].LastModified.After(files[j].LastModified)
+	})
+	for _, file := range files[max:] {
+		if err := w.client.delete(file.Name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ListObjectNames returns the names of all files of the destination
+// relative to its prefix
+func (w *WebDAVDestination) ListObjectNames() ([]string, error) {
+	files, err := w.client.list(w.Prefix)
+	if err != nil {
+		return nil, err
+	}
+	prefix := strings.Trim(w.Prefix, "/") + "/"
+	names := make([]string, 0, len(files))
+	for _, file := range files {
+		names = append(names, strings.TrimPrefix(file.Name, prefix))
+	}
+	return names, nil
+}
+
+// DeleteObjects deletes the files with the provided names relative to the
+// prefix of the destination
+func (w *WebDAVDestination) DeleteObjects(names []string) error {
+	for _, name := range names {
+		if err := w.client.delete(path.Join(w.Prefix, name)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
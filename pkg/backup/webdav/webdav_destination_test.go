@@ -0,0 +1,132 @@
+/*
+Copyright 2020 Backup Operator Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webdav
+
+import (
+	"bytes"
+	"crypto/rand"
+	"fmt"
+	"io"
+	"io/ioutil"
+
+	"github.com/kubism/backup-operator/pkg/backup"
+	"github.com/kubism/backup-operator/pkg/backup/mem"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("WebDAVDestination", func() {
+	It("should upload in a single request", func() {
+		data := []byte("temporarycontent")
+		src, _ := mem.NewBufferSource("single", data)
+		dst := newTestDestination("ns/plan", false)
+		written, err := src.Stream(dst)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(written).To(Equal(int64(len(data))))
+		rc, err := dst.client.download("ns/plan/single")
+		Expect(err).ToNot(HaveOccurred())
+		defer rc.Close()
+		Expect(ioutil.ReadAll(rc)).To(Equal(data))
+	})
+	It("should not replace files with partial uploads", func() {
+		dst := newTestDestination("ns/partial", false)
+		_, err := dst.Store(backup.Object{ID: "file", Data: bytes.NewReader([]byte("complete"))})
+		Expect(err).ToNot(HaveOccurred())
+		_, err = dst.Store(backup.Object{
+			ID:   "file",
+			Data: io.MultiReader(bytes.NewReader([]byte("partial")), failingReader{}),
+		})
+		Expect(err).To(HaveOccurred())
+		rc, err := dst.client.download("ns/partial/file")
+		Expect(err).ToNot(HaveOccurred())
+		defer rc.Close()
+		Expect(ioutil.ReadAll(rc)).To(Equal([]byte("complete")))
+		Expect(dst.ListObjectNames()).To(ConsistOf("file"))
+	})
+	It("should upload in chunks", func() {
+		data := make([]byte, 2*minChunkSize+17)
+		_, err := rand.Read(data)
+		Expect(err).ToNot(HaveOccurred())
+		src, _ := mem.NewBufferSource("chunked", data)
+		dst := newTestDestination("ns/plan", true)
+		written, err := src.Stream(dst)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(written).To(Equal(int64(len(data))))
+		Expect(uploadedChunks(dst, "ns/plan/chunked")).To(Equal(3))
+		rc, err := dst.client.download("ns/plan/chunked")
+		Expect(err).ToNot(HaveOccurred())
+		defer rc.Close()
+		Expect(ioutil.ReadAll(rc)).To(Equal(data))
+	})
+	It("should upload with bearer token", func() {
+		conf := newTestConf(true)
+		conf.Username, conf.Password, conf.BearerToken = "", "", token
+		dst, err := NewWebDAVDestination(&WebDAVDestinationConf{
+			ClientConf: conf,
+			Prefix:     "bearer",
+		})
+		Expect(err).ToNot(HaveOccurred())
+		src, _ := mem.NewBufferSource("token", []byte("temporarycontent"))
+		_, err = src.Stream(dst)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(dst.ListObjectNames()).To(Equal([]string{"token"}))
+	})
+	It("should fail with invalid credentials", func() {
+		conf := newTestConf(false)
+		conf.Password = "invalid"
+		_, err := NewWebDAVDestination(&WebDAVDestinationConf{
+			ClientConf: conf,
+			Prefix:     "invalid",
+		})
+		Expect(err).To(HaveOccurred())
+	})
+	It("should reject too small chunk sizes", func() {
+		_, err := NewWebDAVDestination(&WebDAVDestinationConf{
+			ClientConf: newTestConf(true),
+			ChunkSize:  1024,
+		})
+		Expect(err).To(HaveOccurred())
+	})
+	It("should ensure retention", func() {
+		dst := newTestDestination("retention/plan", false)
+		for _, id := range []string{"backup-1", "backup-2", "backup-3", "backup-4"} {
+			src, _ := mem.NewBufferSource(id, []byte(id))
+			_, err := src.Stream(dst)
+			Expect(err).ToNot(HaveOccurred())
+		}
+		Expect(dst.EnsureRetention(2)).To(Succeed())
+		Expect(dst.ListObjectNames()).To(ConsistOf("backup-3", "backup-4"))
+	})
+	It("should list and delete objects relative to the prefix", func() {
+		dst := newTestDestination("list/plan", true)
+		for _, id := range []string{"backup-1", "oplog/1-2.bson.gz"} {
+			src, _ := mem.NewBufferSource(id, []byte(id))
+			_, err := src.Stream(dst)
+			Expect(err).ToNot(HaveOccurred())
+		}
+		Expect(dst.ListObjectNames()).To(ConsistOf("backup-1", "oplog/1-2.bson.gz"))
+		Expect(dst.DeleteObjects([]string{"oplog/1-2.bson.gz"})).To(Succeed())
+		Expect(dst.ListObjectNames()).To(Equal([]string{"backup-1"}))
+	})
+})
+
+type failingReader struct{}
+
+func (failingReader) Read(p []byte) (int, error) {
+	return 0, fmt.Errorf("broken source")
+}
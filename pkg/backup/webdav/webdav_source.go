@@ -0,0 +1,64 @@
+/*
+Copyright 2020 Backup Operator Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webdav
+
+import (
+	"github.com/kubism/backup-operator/pkg/backup"
+	"github.com/kubism/backup-operator/pkg/logger"
+)
+
+type WebDAVSourceConf struct {
+	ClientConf
+	Key string
+}
+
+func NewWebDAVSource(conf *WebDAVSourceConf) (*WebDAVSource, error) {
+	client, err := newClient(&conf.ClientConf)
+	if err != nil {
+		return nil, err
+	}
+	return &WebDAVSource{
+		client: client,
+		Key:    conf.Key,
+		log:    logger.WithName("webdavsrc"),
+	}, nil
+}
+
+type WebDAVSource struct {
+	client *client
+	Key    string
+	log    logger.Logger
+}
+
+func (w *WebDAVSource) Stream(dst backup.Destination) (int64, error) {
+	log := w.log
+	log.Info("download starting", "url", w.client.Conf.URL, "key", w.Key)
+	data, err := w.client.download(w.Key)
+	if err != nil {
+		return 0, err
+	}
+	defer data.Close()
+	written, err := dst.Store(backup.Object{
+		ID:   w.Key,
+		Data: data,
+	})
+	if err != nil {
+		return written, err
+	}
+	log.Info("finished download", "numBytes", written)
+	return written, nil
+}
@@ -0,0 +1,79 @@
+/*
+Copyright 2020 Backup Operator Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	backupv1alpha1 "github.com/kubism/backup-operator/api/v1alpha1"
+
+	batchv1beta1 "k8s.io/api/batch/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// AdditionalWorkerCronJobName returns the name of the CronJob running the
+// additional worker of the plan with the provided name
+func AdditionalWorkerCronJobName(planName string, worker backupv1alpha1.AdditionalWorker) string {
+	return fmt.Sprintf("%s-%s", planName, worker.Name)
+}
+
+// UpdateAdditionalWorkerCronJob creates or updates the CronJob of the
+// additional worker based on the CronJob of the plan. Runs of the additional
+// worker never overlap. If the worker has no schedule, its CronJob is deleted.
+func UpdateAdditionalWorkerCronJob(ctx context.Context, c client.Client, scheme *runtime.Scheme, owner metav1.Object, planCronJob *batchv1beta1.CronJob, worker backupv1alpha1.AdditionalWorker) error {
+	cronJob := batchv1beta1.CronJob{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: planCronJob.Namespace,
+			Name:      AdditionalWorkerCronJobName(planCronJob.Name, worker),
+		},
+	}
+	if worker.Schedule == "" {
+		return client.IgnoreNotFound(c.Delete(ctx, &cronJob))
+	}
+	_, err := controllerutil.CreateOrUpdate(ctx, c, &cronJob, func() error {
+		cronJob.Spec = *planCronJob.Spec.DeepCopy()
+		cronJob.Spec.Schedule = worker.Schedule
+		cronJob.Spec.ConcurrencyPolicy = batchv1beta1.ForbidConcurrent
+		podSpec := &cronJob.Spec.JobTemplate.Spec.Template.Spec
+		if len(podSpec.Containers) != 1 || len(podSpec.Containers[0].Args) != 2 {
+			return fmt.Errorf("expected exactly one worker container")
+		}
+		podSpec.Containers[0].Args[0] = worker.Cmd
+		return controllerutil.SetControllerReference(owner, &cronJob, scheme)
+	})
+	return err
+}
+
+// DeleteAdditionalWorkerCronJobs deletes the CronJobs of all additional
+// workers of the plan
+func DeleteAdditionalWorkerCronJobs(ctx context.Context, c client.Client, namespace, planName string, workers []backupv1alpha1.AdditionalWorker) error {
+	for _, worker := range workers {
+		if err := c.Delete(ctx, &batchv1beta1.CronJob{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: namespace,
+				Name:      AdditionalWorkerCronJobName(planName, worker),
+			},
+		}); client.IgnoreNotFound(err) != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -26,6 +26,7 @@ import (
 	"github.com/go-logr/logr"
 	batchv1beta1 "k8s.io/api/batch/v1beta1"
 	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
@@ -51,8 +52,30 @@ type BackupPlanReconciler struct {
 // +kubebuilder:rbac:groups=backup.kubism.io,resources=mongodbbackupplans/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=backup.kubism.io,resources=consulbackupplans,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=backup.kubism.io,resources=consulbackupplans/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=backup.kubism.io,resources=postgresqlbackupplans,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=backup.kubism.io,resources=postgresqlbackupplans/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=backup.kubism.io,resources=mysqlbackupplans,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=backup.kubism.io,resources=mysqlbackupplans/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=backup.kubism.io,resources=redisbackupplans,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=backup.kubism.io,resources=redisbackupplans/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=backup.kubism.io,resources=etcdbackupplans,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=backup.kubism.io,resources=etcdbackupplans/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=backup.kubism.io,resources=vaultbackupplans,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=backup.kubism.io,resources=vaultbackupplans/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=backup.kubism.io,resources=volumebackupplans,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=backup.kubism.io,resources=volumebackupplans/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=backup.kubism.io,resources=kubernetesbackupplans,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=backup.kubism.io,resources=kubernetesbackupplans/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=backup.kubism.io,resources=execbackupplans,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=backup.kubism.io,resources=execbackupplans/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=backup.kubism.io,resources=s3backupplans,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=backup.kubism.io,resources=s3backupplans/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=backup.kubism.io,resources=httpbackupplans,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=backup.kubism.io,resources=httpbackupplans/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=batch,resources=cronjobs,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups="",resources=serviceaccounts,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=roles;rolebindings,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
 
 func (r *BackupPlanReconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
@@ -114,6 +137,13 @@ func (r *BackupPlanReconciler) Reconcile(req ctrl.Request) (ctrl.Result, error)
 					status.CronJob = nil
 				}
 			}
+			if p, ok := plan.(backupv1alpha1.AdditionalWorkerProvider); ok {
+				if err := DeleteAdditionalWorkerCronJobs(ctx, r.Client, req.Namespace, req.Name, p.GetAdditionalWorkers()); err != nil {
+					log.Error(err, "failed to remove owned CronJobs of additional workers")
+					r.Recorder.Event(plan, corev1.EventTypeWarning, "Problem", "Failed to remove owned CronJobs of additional workers")
+					return ctrl.Result{}, err
+				}
+			}
 			// Finally remove the finalizer
 			objectMeta.Finalizers = util.RemoveString(objectMeta.Finalizers, finalizerName)
 			if err := r.Update(ctx, plan); err != nil {
@@ -214,17 +244,55 @@ func (r *BackupPlanReconciler) Reconcile(req ctrl.Request) (ctrl.Result, error)
 
 	// Properly construct the spec
 	spec := plan.GetSpec()
+	volumes, volumeMounts := spec.Volumes, spec.VolumeMounts
+	if p, ok := plan.(backupv1alpha1.WorkerVolumeProvider); ok {
+		// copy to make sure the spec of the plan is not modified
+		workerVolumes, workerVolumeMounts := p.GetWorkerVolumes()
+		volumes = append(append([]corev1.Volume{}, volumes...), workerVolumes...)
+		volumeMounts = append(append([]corev1.VolumeMount{}, volumeMounts...), workerVolumeMounts...)
+	}
+	if spec.Destination != nil {
+		destinationVolumes, destinationVolumeMounts := spec.Destination.GetWorkerVolumes()
+		volumes = append(append([]corev1.Volume{}, volumes...), destinationVolumes...)
+		volumeMounts = append(append([]corev1.VolumeMount{}, volumeMounts...), destinationVolumeMounts...)
+	}
 	err = UpdateCronJobSpec(&cronJob, secretRef,
 		spec.Schedule,
 		spec.ActiveDeadlineSeconds,
 		r.WorkerImage,
 		spec.Env,
 		plan.GetCmd(),
-		spec.Volumes,
-		spec.VolumeMounts) // TODO: const?
+		volumes,
+		volumeMounts) // TODO: const?
 	if err != nil {
 		return ctrl.Result{}, err
 	}
+	var securityContext *corev1.PodSecurityContext
+	if p, ok := plan.(backupv1alpha1.WorkerSecurityContextProvider); ok {
+		securityContext = p.GetWorkerSecurityContext()
+	}
+	if spec.Destination != nil {
+		securityContext = mergeWorkerSecurityContext(securityContext, spec.Destination.GetWorkerSecurityContext())
+	}
+	cronJob.Spec.JobTemplate.Spec.Template.Spec.SecurityContext = securityContext
+	if p, ok := plan.(backupv1alpha1.WorkerImageProvider); ok {
+		if err := UpdateCronJobSpecWithCustomImage(&cronJob, r.WorkerImage, p.GetWorkerImage()); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+	if p, ok := plan.(backupv1alpha1.WorkerRBACProvider); ok {
+		serviceAccountName := p.GetWorkerServiceAccountName()
+		if serviceAccountName == "" {
+			serviceAccountName = req.Name + WorkerRBACNameSuffix
+			err := UpdateWorkerRBAC(ctx, r.Client, r.Scheme, plan, req.Namespace, serviceAccountName, p.GetWorkerPolicyRules())
+			if err != nil {
+				log.Error(err, "failed to create or update worker RBAC")
+				r.Recorder.Event(plan, corev1.EventTypeWarning, "Problem", fmt.Sprintf("Update or creation of worker RBAC failed with: %v", err))
+				return ctrl.Result{}, err
+			}
+		}
+		cronJob.Spec.JobTemplate.Spec.Template.Spec.ServiceAccountName = serviceAccountName
+	}
 
 	// Finally create or update the cronjob
 	if status.CronJob != nil {
@@ -249,6 +317,17 @@ func (r *BackupPlanReconciler) Reconcile(req ctrl.Request) (ctrl.Result, error)
 	}
 	status.CronJob = cronJobRef
 
+	if p, ok := plan.(backupv1alpha1.AdditionalWorkerProvider); ok {
+		for _, worker := range p.GetAdditionalWorkers() {
+			err := UpdateAdditionalWorkerCronJob(ctx, r.Client, r.Scheme, plan, &cronJob, worker)
+			if err != nil {
+				log.Error(err, "failed to create or update CronJob of additional worker", "worker", worker.Name)
+				r.Recorder.Event(plan, corev1.EventTypeWarning, "Problem", fmt.Sprintf("Update or creation of CronJob of worker %s failed with: %v", worker.Name, err))
+				return ctrl.Result{}, err
+			}
+		}
+	}
+
 	if err := r.Update(ctx, plan); err != nil {
 		log.Error(err, "status update failed")
 		r.Recorder.Event(plan, corev1.EventTypeWarning, "Problem", fmt.Sprintf("Failed to update BackupPlan: %v", err))
@@ -259,10 +338,35 @@ func (r *BackupPlanReconciler) Reconcile(req ctrl.Request) (ctrl.Result, error)
 
 func (r *BackupPlanReconciler) SetupWithManager(mgr ctrl.Manager, name string) error {
 	r.Recorder = mgr.GetEventRecorderFor(name)
-	return ctrl.NewControllerManagedBy(mgr).
+	builder := ctrl.NewControllerManagedBy(mgr).
 		For(r.Type).
 		Owns(&corev1.Secret{}).
-		Owns(&batchv1beta1.CronJob{}).
+		Owns(&batchv1beta1.CronJob{})
+	if _, ok := r.Type.(backupv1alpha1.WorkerRBACProvider); ok {
+		builder = builder.
+			Owns(&corev1.ServiceAccount{}).
+			Owns(&rbacv1.Role{}).
+			Owns(&rbacv1.RoleBinding{})
+	}
+	return builder.
 		Named(name).
 		Complete(r)
 }
+
+// mergeWorkerSecurityContext adds the fsGroup required by the destination to
+// the security context required by the plan. If both require a different
+// group, the group of the plan is added as supplemental group instead.
+func mergeWorkerSecurityContext(plan, destination *corev1.PodSecurityContext) *corev1.PodSecurityContext {
+	if plan == nil {
+		return destination
+	}
+	if destination == nil || destination.FSGroup == nil {
+		return plan
+	}
+	merged := plan.DeepCopy()
+	if merged.FSGroup != nil && *merged.FSGroup != *destination.FSGroup {
+		merged.SupplementalGroups = append(merged.SupplementalGroups, *merged.FSGroup)
+	}
+	merged.FSGroup = destination.FSGroup
+	return merged
+}
@@ -26,6 +26,8 @@ import (
 	batchv1 "k8s.io/api/batch/v1"
 	batchv1beta1 "k8s.io/api/batch/v1beta1"
 	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -41,9 +43,19 @@ const (
 )
 
 // Add api types to test here
-var planTypes = [2]backupv1alpha1.BackupPlan{
+var planTypes = [12]backupv1alpha1.BackupPlan{
 	&backupv1alpha1.ConsulBackupPlan{},
 	&backupv1alpha1.MongoDBBackupPlan{},
+	&backupv1alpha1.PostgreSQLBackupPlan{},
+	&backupv1alpha1.MySQLBackupPlan{},
+	&backupv1alpha1.RedisBackupPlan{},
+	&backupv1alpha1.EtcdBackupPlan{},
+	&backupv1alpha1.VaultBackupPlan{},
+	&backupv1alpha1.VolumeBackupPlan{},
+	&backupv1alpha1.KubernetesBackupPlan{},
+	&backupv1alpha1.ExecBackupPlan{},
+	&backupv1alpha1.S3BackupPlan{},
+	&backupv1alpha1.HTTPBackupPlan{},
 }
 
 type CreateNewBackupPlanFunc = func(namespace string) backupv1alpha1.BackupPlan
@@ -56,10 +68,50 @@ var createTypeFuncs = map[string]CreateNewBackupPlanFunc{
 	backupv1alpha1.MongoDBBackupPlanKind: func(namespace string) backupv1alpha1.BackupPlan {
 		return newMongoDBBackupPlan(namespace)
 	},
+	backupv1alpha1.PostgreSQLBackupPlanKind: func(namespace string) backupv1alpha1.BackupPlan {
+		return newPostgreSQLBackupPlan(namespace)
+	},
+	backupv1alpha1.MySQLBackupPlanKind: func(namespace string) backupv1alpha1.BackupPlan {
+		return newMySQLBackupPlan(namespace)
+	},
+	backupv1alpha1.RedisBackupPlanKind: func(namespace string) backupv1alpha1.BackupPlan {
+		return newRedisBackupPlan(namespace)
+	},
+	backupv1alpha1.EtcdBackupPlanKind: func(namespace string) backupv1alpha1.BackupPlan {
+		return newEtcdBackupPlan(namespace)
+	},
+	backupv1alpha1.VaultBackupPlanKind: func(namespace string) backupv1alpha1.BackupPlan {
+		return newVaultBackupPlan(namespace)
+	},
+	backupv1alpha1.VolumeBackupPlanKind: func(namespace string) backupv1alpha1.BackupPlan {
+		return newVolumeBackupPlan(namespace)
+	},
+	backupv1alpha1.KubernetesBackupPlanKind: func(namespace string) backupv1alpha1.BackupPlan {
+		return newKubernetesBackupPlan(namespace)
+	},
+	backupv1alpha1.ExecBackupPlanKind: func(namespace string) backupv1alpha1.BackupPlan {
+		return newExecBackupPlan(namespace)
+	},
+	backupv1alpha1.S3BackupPlanKind: func(namespace string) backupv1alpha1.BackupPlan {
+		return newS3BackupPlan(namespace)
+	},
+	backupv1alpha1.HTTPBackupPlanKind: func(namespace string) backupv1alpha1.BackupPlan {
+		return newHTTPBackupPlan(namespace)
+	},
 }
 
 type UpdateMongoDBBackupPlanFunc = func(spec *backupv1alpha1.MongoDBBackupPlan)
 type UpdateConsulBackupPlanFunc = func(spec *backupv1alpha1.ConsulBackupPlan)
+type UpdatePostgreSQLBackupPlanFunc = func(spec *backupv1alpha1.PostgreSQLBackupPlan)
+type UpdateMySQLBackupPlanFunc = func(spec *backupv1alpha1.MySQLBackupPlan)
+type UpdateRedisBackupPlanFunc = func(spec *backupv1alpha1.RedisBackupPlan)
+type UpdateEtcdBackupPlanFunc = func(spec *backupv1alpha1.EtcdBackupPlan)
+type UpdateVaultBackupPlanFunc = func(spec *backupv1alpha1.VaultBackupPlan)
+type UpdateVolumeBackupPlanFunc = func(spec *backupv1alpha1.VolumeBackupPlan)
+type UpdateKubernetesBackupPlanFunc = func(spec *backupv1alpha1.KubernetesBackupPlan)
+type UpdateExecBackupPlanFunc = func(spec *backupv1alpha1.ExecBackupPlan)
+type UpdateS3BackupPlanFunc = func(spec *backupv1alpha1.S3BackupPlan)
+type UpdateHTTPBackupPlanFunc = func(spec *backupv1alpha1.HTTPBackupPlan)
 
 func newObjectMeta(namespace string) metav1.ObjectMeta {
 	return metav1.ObjectMeta{
@@ -115,6 +167,146 @@ func newMongoDBBackupPlan(namespace string, updates ...UpdateMongoDBBackupPlanFu
 	return plan
 }
 
+func newPostgreSQLBackupPlan(namespace string, updates ...UpdatePostgreSQLBackupPlanFunc) backupv1alpha1.BackupPlan {
+	plan := &backupv1alpha1.PostgreSQLBackupPlan{
+		ObjectMeta: newObjectMeta(namespace),
+		Spec: backupv1alpha1.PostgreSQLBackupPlanSpec{
+			BackupPlanSpec: newBackupPlanSpec(namespace),
+			URI:            "postgres://localhost:5432/postgres",
+		},
+	}
+	for _, f := range updates {
+		f(plan)
+	}
+	return plan
+}
+
+func newMySQLBackupPlan(namespace string, updates ...UpdateMySQLBackupPlanFunc) backupv1alpha1.BackupPlan {
+	plan := &backupv1alpha1.MySQLBackupPlan{
+		ObjectMeta: newObjectMeta(namespace),
+		Spec: backupv1alpha1.MySQLBackupPlanSpec{
+			BackupPlanSpec: newBackupPlanSpec(namespace),
+			Address:        "localhost:3306",
+		},
+	}
+	for _, f := range updates {
+		f(plan)
+	}
+	return plan
+}
+
+func newRedisBackupPlan(namespace string, updates ...UpdateRedisBackupPlanFunc) backupv1alpha1.BackupPlan {
+	plan := &backupv1alpha1.RedisBackupPlan{
+		ObjectMeta: newObjectMeta(namespace),
+		Spec: backupv1alpha1.RedisBackupPlanSpec{
+			BackupPlanSpec: newBackupPlanSpec(namespace),
+			Address:        "localhost:6379",
+		},
+	}
+	for _, f := range updates {
+		f(plan)
+	}
+	return plan
+}
+
+func newEtcdBackupPlan(namespace string, updates ...UpdateEtcdBackupPlanFunc) backupv1alpha1.BackupPlan {
+	plan := &backupv1alpha1.EtcdBackupPlan{
+		ObjectMeta: newObjectMeta(namespace),
+		Spec: backupv1alpha1.EtcdBackupPlanSpec{
+			BackupPlanSpec: newBackupPlanSpec(namespace),
+			Endpoints:      []string{"localhost:2379"},
+		},
+	}
+	for _, f := range updates {
+		f(plan)
+	}
+	return plan
+}
+
+func newVaultBackupPlan(namespace string, updates ...UpdateVaultBackupPlanFunc) backupv1alpha1.BackupPlan {
+	plan := &backupv1alpha1.VaultBackupPlan{
+		ObjectMeta: newObjectMeta(namespace),
+		Spec: backupv1alpha1.VaultBackupPlanSpec{
+			BackupPlanSpec: newBackupPlanSpec(namespace),
+			Address:        "http://localhost:8200",
+		},
+	}
+	for _, f := range updates {
+		f(plan)
+	}
+	return plan
+}
+
+func newVolumeBackupPlan(namespace string, updates ...UpdateVolumeBackupPlanFunc) backupv1alpha1.BackupPlan {
+	plan := &backupv1alpha1.VolumeBackupPlan{
+		ObjectMeta: newObjectMeta(namespace),
+		Spec: backupv1alpha1.VolumeBackupPlanSpec{
+			BackupPlanSpec: newBackupPlanSpec(namespace),
+			ClaimName:      "data",
+		},
+	}
+	for _, f := range updates {
+		f(plan)
+	}
+	return plan
+}
+
+func newKubernetesBackupPlan(namespace string, updates ...UpdateKubernetesBackupPlanFunc) backupv1alpha1.BackupPlan {
+	plan := &backupv1alpha1.KubernetesBackupPlan{
+		ObjectMeta: newObjectMeta(namespace),
+		Spec: backupv1alpha1.KubernetesBackupPlanSpec{
+			BackupPlanSpec: newBackupPlanSpec(namespace),
+			LabelSelector:  "app=test",
+		},
+	}
+	for _, f := range updates {
+		f(plan)
+	}
+	return plan
+}
+
+func newExecBackupPlan(namespace string, updates ...UpdateExecBackupPlanFunc) backupv1alpha1.BackupPlan {
+	plan := &backupv1alpha1.ExecBackupPlan{
+		ObjectMeta: newObjectMeta(namespace),
+		Spec: backupv1alpha1.ExecBackupPlanSpec{
+			BackupPlanSpec: newBackupPlanSpec(namespace),
+			Image:          "alpine", Command: []string{"echo", "test"},
+		},
+	}
+	for _, f := range updates {
+		f(plan)
+	}
+	return plan
+}
+
+func newS3BackupPlan(namespace string, updates ...UpdateS3BackupPlanFunc) backupv1alpha1.BackupPlan {
+	plan := &backupv1alpha1.S3BackupPlan{
+		ObjectMeta: newObjectMeta(namespace),
+		Spec: backupv1alpha1.S3BackupPlanSpec{
+			BackupPlanSpec: newBackupPlanSpec(namespace),
+			Source:         backupv1alpha1.S3{Endpoint: "localhost:9000", Bucket: "uploads"},
+		},
+	}
+	for _, f := range updates {
+		f(plan)
+	}
+	return plan
+}
+
+func newHTTPBackupPlan(namespace string, updates ...UpdateHTTPBackupPlanFunc) backupv1alpha1.BackupPlan {
+	plan := &backupv1alpha1.HTTPBackupPlan{
+		ObjectMeta: newObjectMeta(namespace),
+		Spec: backupv1alpha1.HTTPBackupPlanSpec{
+			BackupPlanSpec: newBackupPlanSpec(namespace),
+			URL:            "http://grafana:3000/api/dashboards",
+		},
+	}
+	for _, f := range updates {
+		f(plan)
+	}
+	return plan
+}
+
 func mustCreateNewMongoDBBackupPlan(namespace string, updates ...UpdateMongoDBBackupPlanFunc) backupv1alpha1.BackupPlan {
 	plan := newMongoDBBackupPlan(namespace, updates...)
 	Expect(k8sClient.Create(context.Background(), plan)).Should(Succeed())
@@ -378,4 +570,277 @@ fi
 		}
 		Expect(k8sClient.Delete(ctx, plan)).Should(Succeed())
 	})
+	It("creates CronJob shipping the oplog", func() {
+		plan := mustCreateNewMongoDBBackupPlan(namespace, func(p *backupv1alpha1.MongoDBBackupPlan) {
+			p.Spec.Oplog = &backupv1alpha1.MongoDBOplog{Schedule: "*/5 * * * *"}
+		})
+		defer mustRemoveFinalizers(plan)
+		res := mustReconcile(plan)
+		Expect(res.Requeue).To(Equal(false))
+		var cronJob batchv1beta1.CronJob
+		Expect(k8sClient.Get(ctx, types.NamespacedName{
+			Namespace: namespace,
+			Name:      plan.GetName() + "-" + backupv1alpha1.MongoDBBackupPlanOplogWorkerName,
+		}, &cronJob)).Should(Succeed())
+		Expect(cronJob.Spec.Schedule).To(Equal("*/5 * * * *"))
+		Expect(cronJob.Spec.ConcurrencyPolicy).To(Equal(batchv1beta1.ForbidConcurrent))
+		podSpec := cronJob.Spec.JobTemplate.Spec.Template.Spec
+		Expect(podSpec.Containers[0].Args).To(Equal([]string{backupv1alpha1.MongoDBBackupPlanOplogWorkerCommand, WorkerConfigFilePath}))
+		// disabling the oplog removes the CronJob
+		Expect(k8sClient.Get(ctx, namespacedName(plan), plan)).Should(Succeed())
+		plan.(*backupv1alpha1.MongoDBBackupPlan).Spec.Oplog = nil
+		Expect(k8sClient.Update(ctx, plan)).Should(Succeed())
+		_ = mustReconcile(plan)
+		err := k8sClient.Get(ctx, namespacedName(&cronJob), &cronJob)
+		Expect(apierrors.IsNotFound(err)).To(BeTrue())
+	})
+})
+
+// Volume specific tests
+var _ = Describe("VolumeBackupPlanReconciler", func() {
+	ctx := context.Background()
+	namespace := ""
+
+	BeforeEach(func() {
+		namespace = mustCreateNamespace()
+	})
+	AfterEach(func() {
+		mustDeleteNamespace(namespace)
+	})
+
+	It("mounts claim read-only", func() {
+		plan := newVolumeBackupPlan(namespace, func(p *backupv1alpha1.VolumeBackupPlan) {
+			p.Spec.SubPath = "data"
+		})
+		Expect(k8sClient.Create(ctx, plan)).Should(Succeed())
+		defer mustRemoveFinalizers(plan)
+		res := mustReconcile(plan)
+		Expect(res.Requeue).To(Equal(false))
+		Expect(k8sClient.Get(ctx, namespacedName(plan), plan)).Should(Succeed())
+		var cronJob batchv1beta1.CronJob
+		Expect(k8sClient.Get(ctx, types.NamespacedName{
+			Namespace: plan.GetStatus().CronJob.Namespace,
+			Name:      plan.GetStatus().CronJob.Name,
+		}, &cronJob)).Should(Succeed())
+		podSpec := cronJob.Spec.JobTemplate.Spec.Template.Spec
+		Expect(podSpec.Volumes).To(ContainElement(corev1.Volume{
+			Name: backupv1alpha1.VolumeBackupPlanVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+					ClaimName: "data",
+					ReadOnly:  true,
+				},
+			},
+		}))
+		Expect(podSpec.Containers[0].VolumeMounts).To(ContainElement(corev1.VolumeMount{
+			Name:      backupv1alpha1.VolumeBackupPlanVolumeName,
+			MountPath: backupv1alpha1.VolumeBackupPlanMountPath,
+			SubPath:   "data",
+			ReadOnly:  true,
+		}))
+		Expect(plan.GetSpec().Volumes).To(BeEmpty())
+	})
+	It("mounts destination claim", func() {
+		plan := newVolumeBackupPlan(namespace, func(p *backupv1alpha1.VolumeBackupPlan) {
+			p.Spec.Destination = &backupv1alpha1.Destination{
+				Volume: &backupv1alpha1.Volume{
+					ClaimName: "backups",
+					SubPath:   "cluster",
+				},
+			}
+		})
+		Expect(k8sClient.Create(ctx, plan)).Should(Succeed())
+		defer mustRemoveFinalizers(plan)
+		res := mustReconcile(plan)
+		Expect(res.Requeue).To(Equal(false))
+		Expect(k8sClient.Get(ctx, namespacedName(plan), plan)).Should(Succeed())
+		var cronJob batchv1beta1.CronJob
+		Expect(k8sClient.Get(ctx, types.NamespacedName{
+			Namespace: plan.GetStatus().CronJob.Namespace,
+			Name:      plan.GetStatus().CronJob.Name,
+		}, &cronJob)).Should(Succeed())
+		podSpec := cronJob.Spec.JobTemplate.Spec.Template.Spec
+		Expect(podSpec.Volumes).To(ContainElement(corev1.Volume{
+			Name: backupv1alpha1.DestinationVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+					ClaimName: "backups",
+				},
+			},
+		}))
+		Expect(podSpec.Containers[0].VolumeMounts).To(ContainElement(corev1.VolumeMount{
+			Name:      backupv1alpha1.DestinationVolumeName,
+			MountPath: backupv1alpha1.DestinationVolumeMountPath,
+			SubPath:   "cluster",
+		}))
+		Expect(podSpec.SecurityContext).ToNot(BeNil())
+		Expect(*podSpec.SecurityContext.FSGroup).To(Equal(backupv1alpha1.DefaultDestinationVolumeFSGroup))
+		Expect(podSpec.Volumes).To(ContainElement(HaveField("Name", backupv1alpha1.VolumeBackupPlanVolumeName)))
+		Expect(plan.GetSpec().Volumes).To(BeEmpty())
+	})
+	It("runs worker with configured user and groups", func() {
+		runAsUser, fsGroup, destinationFSGroup := int64(0), int64(1000), int64(2000)
+		plan := newVolumeBackupPlan(namespace, func(p *backupv1alpha1.VolumeBackupPlan) {
+			p.Spec.RunAsUser = &runAsUser
+			p.Spec.FSGroup = &fsGroup
+			p.Spec.Destination = &backupv1alpha1.Destination{
+				Volume: &backupv1alpha1.Volume{
+					ClaimName: "backups",
+					FSGroup:   &destinationFSGroup,
+				},
+			}
+		})
+		Expect(k8sClient.Create(ctx, plan)).Should(Succeed())
+		defer mustRemoveFinalizers(plan)
+		res := mustReconcile(plan)
+		Expect(res.Requeue).To(Equal(false))
+		Expect(k8sClient.Get(ctx, namespacedName(plan), plan)).Should(Succeed())
+		var cronJob batchv1beta1.CronJob
+		Expect(k8sClient.Get(ctx, types.NamespacedName{
+			Namespace: plan.GetStatus().CronJob.Namespace,
+			Name:      plan.GetStatus().CronJob.Name,
+		}, &cronJob)).Should(Succeed())
+		securityContext := cronJob.Spec.JobTemplate.Spec.Template.Spec.SecurityContext
+		Expect(securityContext).ToNot(BeNil())
+		Expect(*securityContext.RunAsUser).To(Equal(runAsUser))
+		Expect(*securityContext.FSGroup).To(Equal(destinationFSGroup))
+		Expect(securityContext.SupplementalGroups).To(ConsistOf(fsGroup))
+	})
+})
+
+// Kubernetes specific tests
+var _ = Describe("KubernetesBackupPlanReconciler", func() {
+	ctx := context.Background()
+	namespace := ""
+
+	BeforeEach(func() {
+		namespace = mustCreateNamespace()
+	})
+	AfterEach(func() {
+		mustDeleteNamespace(namespace)
+	})
+
+	getPodSpec := func(plan backupv1alpha1.BackupPlan) corev1.PodSpec {
+		var cronJob batchv1beta1.CronJob
+		Expect(k8sClient.Get(ctx, types.NamespacedName{
+			Namespace: plan.GetStatus().CronJob.Namespace,
+			Name:      plan.GetStatus().CronJob.Name,
+		}, &cronJob)).Should(Succeed())
+		return cronJob.Spec.JobTemplate.Spec.Template.Spec
+	}
+
+	It("creates ServiceAccount with read access", func() {
+		plan := newKubernetesBackupPlan(namespace, func(p *backupv1alpha1.KubernetesBackupPlan) {
+			p.Spec.Resources = []string{"configmaps", "deployments.apps"}
+		})
+		Expect(k8sClient.Create(ctx, plan)).Should(Succeed())
+		defer mustRemoveFinalizers(plan)
+		res := mustReconcile(plan)
+		Expect(res.Requeue).To(Equal(false))
+		Expect(k8sClient.Get(ctx, namespacedName(plan), plan)).Should(Succeed())
+		workerName := types.NamespacedName{
+			Namespace: namespace,
+			Name:      plan.GetName() + WorkerRBACNameSuffix,
+		}
+		Expect(getPodSpec(plan).ServiceAccountName).To(Equal(workerName.Name))
+		var serviceAccount corev1.ServiceAccount
+		Expect(k8sClient.Get(ctx, workerName, &serviceAccount)).Should(Succeed())
+		var role rbacv1.Role
+		Expect(k8sClient.Get(ctx, workerName, &role)).Should(Succeed())
+		Expect(role.Rules).To(ConsistOf(
+			rbacv1.PolicyRule{APIGroups: []string{"*"}, Resources: []string{"configmaps"}, Verbs: []string{"get", "list"}},
+			rbacv1.PolicyRule{APIGroups: []string{"apps"}, Resources: []string{"deployments"}, Verbs: []string{"get", "list"}},
+		))
+		var roleBinding rbacv1.RoleBinding
+		Expect(k8sClient.Get(ctx, workerName, &roleBinding)).Should(Succeed())
+		Expect(roleBinding.RoleRef.Name).To(Equal(role.Name))
+		Expect(roleBinding.Subjects).To(ConsistOf(rbacv1.Subject{
+			Kind:      rbacv1.ServiceAccountKind,
+			Namespace: namespace,
+			Name:      serviceAccount.Name,
+		}))
+		// rules should be updated as well
+		kubernetesPlan := plan.(*backupv1alpha1.KubernetesBackupPlan)
+		kubernetesPlan.Spec.Resources = nil
+		Expect(k8sClient.Update(ctx, plan)).Should(Succeed())
+		_ = mustReconcile(plan)
+		Expect(k8sClient.Get(ctx, workerName, &role)).Should(Succeed())
+		Expect(role.Rules).To(ConsistOf(
+			rbacv1.PolicyRule{APIGroups: []string{"*"}, Resources: []string{"*"}, Verbs: []string{"get", "list"}},
+		))
+	})
+	It("uses provided ServiceAccount", func() {
+		plan := newKubernetesBackupPlan(namespace, func(p *backupv1alpha1.KubernetesBackupPlan) {
+			p.Spec.ServiceAccountName = "backup"
+		})
+		Expect(k8sClient.Create(ctx, plan)).Should(Succeed())
+		defer mustRemoveFinalizers(plan)
+		_ = mustReconcile(plan)
+		Expect(k8sClient.Get(ctx, namespacedName(plan), plan)).Should(Succeed())
+		Expect(getPodSpec(plan).ServiceAccountName).To(Equal("backup"))
+		var serviceAccount corev1.ServiceAccount
+		err := k8sClient.Get(ctx, types.NamespacedName{
+			Namespace: namespace,
+			Name:      plan.GetName() + WorkerRBACNameSuffix,
+		}, &serviceAccount)
+		Expect(apierrors.IsNotFound(err)).To(BeTrue())
+	})
+	It("does not take over ServiceAccount named after the plan", func() {
+		plan := newKubernetesBackupPlan(namespace)
+		existing := corev1.ServiceAccount{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: plan.GetName()},
+		}
+		Expect(k8sClient.Create(ctx, &existing)).Should(Succeed())
+		Expect(k8sClient.Create(ctx, plan)).Should(Succeed())
+		defer mustRemoveFinalizers(plan)
+		_ = mustReconcile(plan)
+		Expect(k8sClient.Get(ctx, namespacedName(&existing), &existing)).Should(Succeed())
+		Expect(existing.OwnerReferences).To(BeEmpty())
+	})
+})
+
+// Exec specific tests
+var _ = Describe("ExecBackupPlanReconciler", func() {
+	ctx := context.Background()
+	namespace := ""
+
+	BeforeEach(func() {
+		namespace = mustCreateNamespace()
+	})
+	AfterEach(func() {
+		mustDeleteNamespace(namespace)
+	})
+
+	It("installs worker into custom image", func() {
+		plan := newExecBackupPlan(namespace, func(p *backupv1alpha1.ExecBackupPlan) {
+			p.Spec.Image = "clickhouse/clickhouse-server"
+		})
+		Expect(k8sClient.Create(ctx, plan)).Should(Succeed())
+		defer mustRemoveFinalizers(plan)
+		res := mustReconcile(plan)
+		Expect(res.Requeue).To(Equal(false))
+		Expect(k8sClient.Get(ctx, namespacedName(plan), plan)).Should(Succeed())
+		var cronJob batchv1beta1.CronJob
+		Expect(k8sClient.Get(ctx, types.NamespacedName{
+			Namespace: plan.GetStatus().CronJob.Namespace,
+			Name:      plan.GetStatus().CronJob.Name,
+		}, &cronJob)).Should(Succeed())
+		podSpec := cronJob.Spec.JobTemplate.Spec.Template.Spec
+		Expect(podSpec.InitContainers).To(HaveLen(1))
+		Expect(podSpec.InitContainers[0].Image).To(Equal("test"))
+		Expect(podSpec.InitContainers[0].Command).To(ContainElement(WorkerBinFilePath))
+		Expect(podSpec.Containers).To(HaveLen(1))
+		Expect(podSpec.Containers[0].Image).To(Equal("clickhouse/clickhouse-server"))
+		Expect(podSpec.Containers[0].Command).To(Equal([]string{WorkerBinFilePath}))
+		Expect(podSpec.Containers[0].VolumeMounts).To(ContainElement(corev1.VolumeMount{
+			Name:      WorkerBinVolumeName,
+			MountPath: WorkerBinMountPath,
+			ReadOnly:  true,
+		}))
+		// updates must not add the volume twice
+		_ = mustReconcile(plan)
+		Expect(k8sClient.Get(ctx, namespacedName(&cronJob), &cronJob)).Should(Succeed())
+		podSpec = cronJob.Spec.JobTemplate.Spec.Template.Spec
+		Expect(podSpec.Volumes).To(HaveLen(2))
+	})
 })
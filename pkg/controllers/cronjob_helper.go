@@ -17,6 +17,7 @@ limitations under the License.
 package controllers
 
 import (
+	"fmt"
 	"path/filepath"
 
 	batchv1beta1 "k8s.io/api/batch/v1beta1"
@@ -24,13 +25,17 @@ import (
 )
 
 const (
-	WorkerContainerName    = "worker"
-	WorkerConfigVolumeName = "config"
-	WorkerConfigMountPath  = "/etc/worker"
+	WorkerContainerName        = "worker"
+	WorkerConfigVolumeName     = "config"
+	WorkerConfigMountPath      = "/etc/worker"
+	WorkerInstallContainerName = "install-worker"
+	WorkerBinVolumeName        = "worker-bin"
+	WorkerBinMountPath         = "/opt/backup-operator"
 )
 
 var (
 	WorkerConfigFilePath = filepath.Join(WorkerConfigMountPath, "plan.json")
+	WorkerBinFilePath    = filepath.Join(WorkerBinMountPath, "worker")
 )
 
 func UpdateCronJobSpec(cronJob *batchv1beta1.CronJob, secretRef *corev1.ObjectReference, schedule string, activeDeadlineSeconds int64, image string, env []corev1.EnvVar, subcmd string,
@@ -70,3 +75,39 @@ func UpdateCronJobSpec(cronJob *batchv1beta1.CronJob, secretRef *corev1.ObjectRe
 	podSpec.RestartPolicy = corev1.RestartPolicyOnFailure
 	return nil
 }
+
+// UpdateCronJobSpecWithCustomImage changes the worker container of a spec
+// created by UpdateCronJobSpec to use the provided image. The worker binary
+// is copied from the worker image by an init container, so the image only
+// needs to be linux based.
+func UpdateCronJobSpecWithCustomImage(cronJob *batchv1beta1.CronJob, workerImage, image string) error {
+	podSpec := &cronJob.Spec.JobTemplate.Spec.Template.Spec
+	if len(podSpec.Containers) != 1 {
+		return fmt.Errorf("expected exactly one worker container")
+	}
+	podSpec.Volumes = append(podSpec.Volumes, corev1.Volume{
+		Name: WorkerBinVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			EmptyDir: &corev1.EmptyDirVolumeSource{},
+		},
+	})
+	binVolumeMount := corev1.VolumeMount{
+		Name:      WorkerBinVolumeName,
+		MountPath: WorkerBinMountPath,
+	}
+	podSpec.InitContainers = []corev1.Container{
+		{
+			Name:            WorkerInstallContainerName,
+			Image:           workerImage,
+			ImagePullPolicy: corev1.PullIfNotPresent,
+			Command:         []string{"cp", "/worker", WorkerBinFilePath},
+			VolumeMounts:    []corev1.VolumeMount{binVolumeMount},
+		},
+	}
+	container := &podSpec.Containers[0]
+	container.Image = image
+	container.Command = []string{WorkerBinFilePath}
+	binVolumeMount.ReadOnly = true
+	container.VolumeMounts = append(container.VolumeMounts, binVolumeMount)
+	return nil
+}
@@ -0,0 +1,69 @@
+/*
+Copyright 2020 Backup Operator Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// WorkerRBACNameSuffix is appended to the name of the plan to name the
+// ServiceAccount, Role and RoleBinding of the worker, so existing objects
+// named after the plan are never taken over
+const WorkerRBACNameSuffix = "-backup-worker"
+
+// UpdateWorkerRBAC creates or updates a ServiceAccount, Role and RoleBinding
+// with the provided name, which grant the worker the provided rules within
+// the namespace. All objects are owned by the provided owner.
+func UpdateWorkerRBAC(ctx context.Context, c client.Client, scheme *runtime.Scheme, owner metav1.Object, namespace, name string, rules []rbacv1.PolicyRule) error {
+	meta := metav1.ObjectMeta{Namespace: namespace, Name: name}
+	serviceAccount := corev1.ServiceAccount{ObjectMeta: meta}
+	if _, err := controllerutil.CreateOrUpdate(ctx, c, &serviceAccount, func() error {
+		return controllerutil.SetControllerReference(owner, &serviceAccount, scheme)
+	}); err != nil {
+		return err
+	}
+	role := rbacv1.Role{ObjectMeta: meta}
+	if _, err := controllerutil.CreateOrUpdate(ctx, c, &role, func() error {
+		role.Rules = rules
+		return controllerutil.SetControllerReference(owner, &role, scheme)
+	}); err != nil {
+		return err
+	}
+	roleBinding := rbacv1.RoleBinding{ObjectMeta: meta}
+	_, err := controllerutil.CreateOrUpdate(ctx, c, &roleBinding, func() error {
+		// roleRef is immutable, but always identical
+		roleBinding.RoleRef = rbacv1.RoleRef{
+			APIGroup: rbacv1.GroupName,
+			Kind:     "Role",
+			Name:     name,
+		}
+		roleBinding.Subjects = []rbacv1.Subject{{
+			Kind:      rbacv1.ServiceAccountKind,
+			Namespace: namespace,
+			Name:      name,
+		}}
+		return controllerutil.SetControllerReference(owner, &roleBinding, scheme)
+	})
+	return err
+}
@@ -141,6 +141,96 @@ var _ = BeforeSuite(func(done Done) {
 			WorkerImage:        "test",
 			Type:               &backupv1alpha1.ConsulBackupPlan{},
 		},
+		backupv1alpha1.PostgreSQLBackupPlanKind: &BackupPlanReconciler{
+			Client:             k8sClient,
+			Log:                logf.Log.WithName("controllers").WithName("PostgreSQLBackupPlan"),
+			Recorder:           &record.FakeRecorder{},
+			Scheme:             scheme.Scheme,
+			DefaultDestination: nil, // TODO
+			WorkerImage:        "test",
+			Type:               &backupv1alpha1.PostgreSQLBackupPlan{},
+		},
+		backupv1alpha1.MySQLBackupPlanKind: &BackupPlanReconciler{
+			Client:             k8sClient,
+			Log:                logf.Log.WithName("controllers").WithName("MySQLBackupPlan"),
+			Recorder:           &record.FakeRecorder{},
+			Scheme:             scheme.Scheme,
+			DefaultDestination: nil, // TODO
+			WorkerImage:        "test",
+			Type:               &backupv1alpha1.MySQLBackupPlan{},
+		},
+		backupv1alpha1.RedisBackupPlanKind: &BackupPlanReconciler{
+			Client:             k8sClient,
+			Log:                logf.Log.WithName("controllers").WithName("RedisBackupPlan"),
+			Recorder:           &record.FakeRecorder{},
+			Scheme:             scheme.Scheme,
+			DefaultDestination: nil, // TODO
+			WorkerImage:        "test",
+			Type:               &backupv1alpha1.RedisBackupPlan{},
+		},
+		backupv1alpha1.EtcdBackupPlanKind: &BackupPlanReconciler{
+			Client:             k8sClient,
+			Log:                logf.Log.WithName("controllers").WithName("EtcdBackupPlan"),
+			Recorder:           &record.FakeRecorder{},
+			Scheme:             scheme.Scheme,
+			DefaultDestination: nil, // TODO
+			WorkerImage:        "test",
+			Type:               &backupv1alpha1.EtcdBackupPlan{},
+		},
+		backupv1alpha1.VaultBackupPlanKind: &BackupPlanReconciler{
+			Client:             k8sClient,
+			Log:                logf.Log.WithName("controllers").WithName("VaultBackupPlan"),
+			Recorder:           &record.FakeRecorder{},
+			Scheme:             scheme.Scheme,
+			DefaultDestination: nil, // TODO
+			WorkerImage:        "test",
+			Type:               &backupv1alpha1.VaultBackupPlan{},
+		},
+		backupv1alpha1.VolumeBackupPlanKind: &BackupPlanReconciler{
+			Client:             k8sClient,
+			Log:                logf.Log.WithName("controllers").WithName("VolumeBackupPlan"),
+			Recorder:           &record.FakeRecorder{},
+			Scheme:             scheme.Scheme,
+			DefaultDestination: nil, // TODO
+			WorkerImage:        "test",
+			Type:               &backupv1alpha1.VolumeBackupPlan{},
+		},
+		backupv1alpha1.KubernetesBackupPlanKind: &BackupPlanReconciler{
+			Client:             k8sClient,
+			Log:                logf.Log.WithName("controllers").WithName("KubernetesBackupPlan"),
+			Recorder:           &record.FakeRecorder{},
+			Scheme:             scheme.Scheme,
+			DefaultDestination: nil, // TODO
+			WorkerImage:        "test",
+			Type:               &backupv1alpha1.KubernetesBackupPlan{},
+		},
+		backupv1alpha1.ExecBackupPlanKind: &BackupPlanReconciler{
+			Client:             k8sClient,
+			Log:                logf.Log.WithName("controllers").WithName("ExecBackupPlan"),
+			Recorder:           &record.FakeRecorder{},
+			Scheme:             scheme.Scheme,
+			DefaultDestination: nil, // TODO
+			WorkerImage:        "test",
+			Type:               &backupv1alpha1.ExecBackupPlan{},
+		},
+		backupv1alpha1.S3BackupPlanKind: &BackupPlanReconciler{
+			Client:             k8sClient,
+			Log:                logf.Log.WithName("controllers").WithName("S3BackupPlan"),
+			Recorder:           &record.FakeRecorder{},
+			Scheme:             scheme.Scheme,
+			DefaultDestination: nil, // TODO
+			WorkerImage:        "test",
+			Type:               &backupv1alpha1.S3BackupPlan{},
+		},
+		backupv1alpha1.HTTPBackupPlanKind: &BackupPlanReconciler{
+			Client:             k8sClient,
+			Log:                logf.Log.WithName("controllers").WithName("HTTPBackupPlan"),
+			Recorder:           &record.FakeRecorder{},
+			Scheme:             scheme.Scheme,
+			DefaultDestination: nil, // TODO
+			WorkerImage:        "test",
+			Type:               &backupv1alpha1.HTTPBackupPlan{},
+		},
 	}
 
 	close(done)
@@ -190,6 +280,26 @@ func mustReconcile(obj runtime.Object) ctrl.Result {
 		reconciler = reconcilers[backupv1alpha1.MongoDBBackupPlanKind]
 	} else if _, ok := obj.(*backupv1alpha1.ConsulBackupPlan); ok {
 		reconciler = reconcilers[backupv1alpha1.ConsulBackupPlanKind]
+	} else if _, ok := obj.(*backupv1alpha1.PostgreSQLBackupPlan); ok {
+		reconciler = reconcilers[backupv1alpha1.PostgreSQLBackupPlanKind]
+	} else if _, ok := obj.(*backupv1alpha1.MySQLBackupPlan); ok {
+		reconciler = reconcilers[backupv1alpha1.MySQLBackupPlanKind]
+	} else if _, ok := obj.(*backupv1alpha1.RedisBackupPlan); ok {
+		reconciler = reconcilers[backupv1alpha1.RedisBackupPlanKind]
+	} else if _, ok := obj.(*backupv1alpha1.EtcdBackupPlan); ok {
+		reconciler = reconcilers[backupv1alpha1.EtcdBackupPlanKind]
+	} else if _, ok := obj.(*backupv1alpha1.VaultBackupPlan); ok {
+		reconciler = reconcilers[backupv1alpha1.VaultBackupPlanKind]
+	} else if _, ok := obj.(*backupv1alpha1.VolumeBackupPlan); ok {
+		reconciler = reconcilers[backupv1alpha1.VolumeBackupPlanKind]
+	} else if _, ok := obj.(*backupv1alpha1.KubernetesBackupPlan); ok {
+		reconciler = reconcilers[backupv1alpha1.KubernetesBackupPlanKind]
+	} else if _, ok := obj.(*backupv1alpha1.ExecBackupPlan); ok {
+		reconciler = reconcilers[backupv1alpha1.ExecBackupPlanKind]
+	} else if _, ok := obj.(*backupv1alpha1.S3BackupPlan); ok {
+		reconciler = reconcilers[backupv1alpha1.S3BackupPlanKind]
+	} else if _, ok := obj.(*backupv1alpha1.HTTPBackupPlan); ok {
+		reconciler = reconcilers[backupv1alpha1.HTTPBackupPlanKind]
 	} else {
 		panic("deadcode, otherwise reconciler was not properly registered for test")
 	}
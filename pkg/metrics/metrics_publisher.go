@@ -19,6 +19,7 @@ package metrics
 import (
 	"fmt"
 	"os"
+	"sort"
 	"time"
 
 	"github.com/kubism/backup-operator/pkg/logger"
@@ -31,6 +32,8 @@ type MetricsPublisher interface {
 	StopTimer()
 	SetSuccessfulRun()
 	SetBackupSizeInBytes(sizeInBytes int64)
+	SetSourceInfo(labels map[string]string)
+	SetSourceValue(name string, value float64)
 	PublishMetrics()
 }
 
@@ -167,6 +170,31 @@ func (m *metricsPublisher) SetBackupSizeInBytes(sizeInBytes int64) {
 	m.sizeInBytes.Set(float64(sizeInBytes))
 }
 
+func (m *metricsPublisher) SetSourceInfo(labels map[string]string) {
+	names := make([]string, 0, len(labels))
+	for name := range labels {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	info := prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "backup_source_info",
+		Help: "Information about the source of the last backup, e.g. the member read.",
+	}, names)
+	info.With(labels).Set(1)
+	m.pusher.Collector(info)
+}
+
+// SetSourceValue publishes a value reported by the source as gauge named
+// backup_source_<name>, e.g. the raft index of a snapshot
+func (m *metricsPublisher) SetSourceValue(name string, value float64) {
+	gauge := prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "backup_source_" + name,
+		Help: "Value reported by the source of the last backup.",
+	})
+	gauge.Set(value)
+	m.pusher.Collector(gauge)
+}
+
 func (m *metricsPublisher) PublishMetrics() {
 	err := m.pusher.Add()
 	if err != nil { // TODO: should we error for real?
@@ -191,5 +219,11 @@ func (n nopMetricsPublisher) SetSuccessfulRun() {
 func (n nopMetricsPublisher) SetBackupSizeInBytes(_ int64) {
 }
 
+func (n nopMetricsPublisher) SetSourceInfo(_ map[string]string) {
+}
+
+func (n nopMetricsPublisher) SetSourceValue(_ string, _ float64) {
+}
+
 func (n nopMetricsPublisher) PublishMetrics() {
 }
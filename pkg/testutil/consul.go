@@ -33,3 +33,39 @@ func WaitForConsul(pool *dockertest.Pool, endpoint string) error {
 		return err
 	})
 }
+
+// InsertConsulTestData writes the provided keys and values into the KV store
+func InsertConsulTestData(endpoint string, data map[string]string) error {
+	consulConf := consulApi.DefaultConfig()
+	consulConf.Address = endpoint
+	client, err := consulApi.NewClient(consulConf)
+	if err != nil {
+		return err
+	}
+	for key, value := range data {
+		_, err := client.KV().Put(&consulApi.KVPair{Key: key, Value: []byte(value)}, nil)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetConsulTestData returns all keys and values of the KV store
+func GetConsulTestData(endpoint string) (map[string]string, error) {
+	consulConf := consulApi.DefaultConfig()
+	consulConf.Address = endpoint
+	client, err := consulApi.NewClient(consulConf)
+	if err != nil {
+		return nil, err
+	}
+	pairs, _, err := client.KV().List("", nil)
+	if err != nil {
+		return nil, err
+	}
+	data := map[string]string{}
+	for _, pair := range pairs {
+		data[pair.Key] = string(pair.Value)
+	}
+	return data, nil
+}
@@ -19,6 +19,7 @@ package testutil
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/ory/dockertest/v3"
@@ -91,3 +92,117 @@ func FindTestData(uri string) error {
 	}
 	return nil
 }
+
+func connectMongoDB(uri string) (*mongo.Client, error) {
+	client, err := mongo.NewClient(options.Client().ApplyURI(uri))
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := client.Connect(ctx); err != nil {
+		return nil, err
+	}
+	return client, nil
+}
+
+// InsertNamespaceTestData inserts a document into every provided namespace
+// in the format of <database>.<collection>
+func InsertNamespaceTestData(uri string, namespaces ...string) error {
+	client, err := connectMongoDB(uri)
+	if err != nil {
+		return err
+	}
+	defer client.Disconnect(context.Background())
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	for _, ns := range namespaces {
+		parts := strings.SplitN(ns, ".", 2)
+		if len(parts) != 2 {
+			return fmt.Errorf("invalid namespace: %s", ns)
+		}
+		_, err := client.Database(parts[0]).Collection(parts[1]).InsertOne(ctx, bson.M{"namespace": ns})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ListNamespaces returns all namespaces of the provided database in the
+// format of <database>.<collection>
+func ListNamespaces(uri, database string) ([]string, error) {
+	client, err := connectMongoDB(uri)
+	if err != nil {
+		return nil, err
+	}
+	defer client.Disconnect(context.Background())
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	names, err := client.Database(database).ListCollectionNames(ctx, bson.D{})
+	if err != nil {
+		return nil, err
+	}
+	namespaces := make([]string, 0, len(names))
+	for _, name := range names {
+		namespaces = append(namespaces, database+"."+name)
+	}
+	return namespaces, nil
+}
+
+// InitiateMongoDBReplicaSet initiates a single member replica set and waits
+// until the member became primary. The uri has to connect directly.
+func InitiateMongoDBReplicaSet(pool *dockertest.Pool, uri, name string) error {
+	client, err := connectMongoDB(uri)
+	if err != nil {
+		return err
+	}
+	defer client.Disconnect(context.Background())
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	err = client.Database("admin").RunCommand(ctx, bson.D{{Key: "replSetInitiate", Value: bson.M{
+		"_id":     name,
+		"members": bson.A{bson.M{"_id": 0, "host": "localhost:27017"}},
+	}}}).Err()
+	if err != nil {
+		return err
+	}
+	return pool.Retry(func() error {
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		defer cancel()
+		var res struct {
+			IsMaster bool `bson:"ismaster"`
+		}
+		if err := client.Database("admin").RunCommand(ctx, bson.D{{Key: "isMaster", Value: 1}}).Decode(&res); err != nil {
+			return err
+		}
+		if !res.IsMaster {
+			return fmt.Errorf("replica set member is not primary yet")
+		}
+		return nil
+	})
+}
+
+// InsertBenchmarkData inserts the provided number of documents into each of
+// the provided number of collections of the database
+func InsertBenchmarkData(uri, database string, collections, documents int) error {
+	client, err := connectMongoDB(uri)
+	if err != nil {
+		return err
+	}
+	defer client.Disconnect(context.Background())
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+	payload := strings.Repeat("x", 1024)
+	for c := 0; c < collections; c++ {
+		docs := make([]interface{}, documents)
+		for d := range docs {
+			docs[d] = bson.M{"index": d, "payload": payload}
+		}
+		_, err := client.Database(database).Collection(fmt.Sprintf("collection%d", c)).InsertMany(ctx, docs)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+/*
+Copyright 2020 Backup Operator Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testutil
+
+import (
+	"database/sql"
+	"fmt"
+
+	_ "github.com/lib/pq" // register driver
+	"github.com/ory/dockertest/v3"
+)
+
+func WaitForPostgreSQL(pool *dockertest.Pool, uri string) error {
+	return pool.Retry(func() error {
+		db, err := sql.Open("postgres", uri)
+		if err != nil {
+			return err
+		}
+		defer db.Close()
+		return db.Ping()
+	})
+}
+
+func InsertPostgreSQLTestData(uri string) error {
+	db, err := sql.Open("postgres", uri)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+	_, err = db.Exec("CREATE TABLE numbers (name TEXT PRIMARY KEY, value DOUBLE PRECISION)")
+	if err != nil {
+		return err
+	}
+	_, err = db.Exec("INSERT INTO numbers (name, value) VALUES ($1, $2)", "pi", 3.14159)
+	return err
+}
+
+func FindPostgreSQLTestData(uri string) error {
+	db, err := sql.Open("postgres", uri)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+	var n int
+	err = db.QueryRow("SELECT COUNT(*) FROM numbers").Scan(&n)
+	if err != nil {
+		return err
+	}
+	if n < 1 {
+		return fmt.Errorf("found insufficient rows: %d", n)
+	}
+	return nil
+}
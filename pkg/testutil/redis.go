@@ -0,0 +1,62 @@
+/*
+Copyright 2020 Backup Operator Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testutil
+
+import (
+	"fmt"
+
+	"github.com/gomodule/redigo/redis"
+	"github.com/ory/dockertest/v3"
+)
+
+func WaitForRedis(pool *dockertest.Pool, address, password string) error {
+	return pool.Retry(func() error {
+		c, err := redis.Dial("tcp", address, redis.DialPassword(password))
+		if err != nil {
+			return err
+		}
+		defer c.Close()
+		_, err = c.Do("PING")
+		return err
+	})
+}
+
+func InsertRedisTestData(address, password string) error {
+	c, err := redis.Dial("tcp", address, redis.DialPassword(password))
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+	_, err = c.Do("SET", "pi", "3.14159")
+	return err
+}
+
+func FindRedisTestData(address, password string) error {
+	c, err := redis.Dial("tcp", address, redis.DialPassword(password))
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+	value, err := redis.String(c.Do("GET", "pi"))
+	if err != nil {
+		return err
+	}
+	if value != "3.14159" {
+		return fmt.Errorf("found unexpected value: %s", value)
+	}
+	return nil
+}
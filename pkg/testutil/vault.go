@@ -0,0 +1,118 @@
+/*
+Copyright 2020 Backup Operator Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testutil
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/ory/dockertest/v3"
+)
+
+// VaultRaftConfig configures a single vault server with integrated storage
+const VaultRaftConfig = `{
+	"storage": {"raft": {"path": "/vault/file", "node_id": "node1"}},
+	"listener": {"tcp": {"address": "0.0.0.0:8200", "tls_disable": true}},
+	"api_addr": "http://127.0.0.1:8200",
+	"cluster_addr": "http://127.0.0.1:8201",
+	"disable_mlock": true
+}`
+
+func vaultRequest(method, url, token string, in, out interface{}) error {
+	var body io.Reader
+	if in != nil {
+		raw, err := json.Marshal(in)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(raw)
+	}
+	req, err := http.NewRequest(method, url, body)
+	if err != nil {
+		return err
+	}
+	if token != "" {
+		req.Header.Set("X-Vault-Token", token)
+	}
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return fmt.Errorf("unexpected status %s for %s %s", res.Status, method, url)
+	}
+	if out != nil {
+		return json.NewDecoder(res.Body).Decode(out)
+	}
+	return nil
+}
+
+func WaitForVault(pool *dockertest.Pool, address string) error {
+	return pool.Retry(func() error {
+		var health struct {
+			Initialized bool `json:"initialized"`
+		}
+		// return 200 regardless of the seal status
+		return vaultRequest(http.MethodGet, address+"/v1/sys/health?uninitcode=200&sealedcode=200&standbycode=200", "", nil, &health)
+	})
+}
+
+// InitVault initializes vault with a single unseal key, unseals it and
+// waits until it is active.
+func InitVault(pool *dockertest.Pool, address string) (string, string, error) {
+	var init struct {
+		Keys      []string `json:"keys"`
+		RootToken string   `json:"root_token"`
+	}
+	err := vaultRequest(http.MethodPut, address+"/v1/sys/init", "", map[string]int{
+		"secret_shares":    1,
+		"secret_threshold": 1,
+	}, &init)
+	if err != nil {
+		return "", "", err
+	}
+	if err := UnsealVault(pool, address, init.Keys[0]); err != nil {
+		return "", "", err
+	}
+	return init.RootToken, init.Keys[0], nil
+}
+
+func UnsealVault(pool *dockertest.Pool, address, key string) error {
+	err := vaultRequest(http.MethodPut, address+"/v1/sys/unseal", "", map[string]string{
+		"key": key,
+	}, nil)
+	if err != nil {
+		return err
+	}
+	return pool.Retry(func() error {
+		return vaultRequest(http.MethodGet, address+"/v1/sys/health", "", nil, nil)
+	})
+}
+
+func InsertVaultTestData(address, token string) error {
+	return vaultRequest(http.MethodPut, address+"/v1/sys/policies/acl/test", token, map[string]string{
+		"policy": `path "secret/*" { capabilities = ["read"] }`,
+	}, nil)
+}
+
+func FindVaultTestData(address, token string) error {
+	return vaultRequest(http.MethodGet, address+"/v1/sys/policies/acl/test", token, nil, nil)
+}